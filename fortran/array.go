@@ -0,0 +1,587 @@
+package fortran
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// arrayFunctions is names of arguments of array intrinsic functions of
+// Fortran 90
+var arrayFunctions = map[string][]string{
+	"SUM":         {"ARRAY", "DIM", "MASK"},
+	"PRODUCT":     {"ARRAY", "DIM", "MASK"},
+	"MAXVAL":      {"ARRAY", "DIM", "MASK"},
+	"MINVAL":      {"ARRAY", "DIM", "MASK"},
+	"MAXLOC":      {"ARRAY", "DIM", "MASK"},
+	"MINLOC":      {"ARRAY", "DIM", "MASK"},
+	"COUNT":       {"MASK", "DIM"},
+	"ANY":         {"MASK", "DIM"},
+	"ALL":         {"MASK", "DIM"},
+	"DOT_PRODUCT": {"VECTOR_A", "VECTOR_B"},
+	"MATMUL":      {"MATRIX_A", "MATRIX_B"},
+	"TRANSPOSE":   {"MATRIX"},
+	"SIZE":        {"ARRAY", "DIM"},
+	"SHAPE":       {"SOURCE"},
+	"LBOUND":      {"ARRAY", "DIM"},
+	"UBOUND":      {"ARRAY", "DIM"},
+	"RESHAPE":     {"SOURCE", "SHAPE", "PAD"},
+	"SPREAD":      {"SOURCE", "DIM", "NCOPIES"},
+	"PACK":        {"ARRAY", "MASK", "VECTOR"},
+	"UNPACK":      {"VECTOR", "MASK", "FIELD"},
+	"CSHIFT":      {"ARRAY", "SHIFT", "DIM"},
+	"EOSHIFT":     {"ARRAY", "SHIFT", "BOUNDARY", "DIM"},
+	"MERGE":       {"TSOURCE", "FSOURCE", "MASK"},
+}
+
+func init() {
+	for name := range arrayFunctions {
+		// type of result is type parameter of function
+		specificFunctions[name] = specific{}
+		intrinsicFunction[name] = arrayIntrinsic
+	}
+	specificFunctions["SIZE"] = specific{result: "int"}
+	specificFunctions["SHAPE"] = specific{result: "int"}
+	specificFunctions["ARRAY"] = specific{result: any}
+}
+
+// fixArrayConstructor change array constructor to function
+//
+// Example:
+//  ( / 1 , 2 / )  - intrinsic.ARRAY ( 1 , 2 )
+func (p *parser) fixArrayConstructor(nodes *[]node) {
+	var foundBegin bool
+	var begin int
+	for i := 1; i < len(*nodes); i++ {
+		if !foundBegin {
+			if (*nodes)[i-1].tok == token.LPAREN && (*nodes)[i].tok == token.QUO {
+				begin = i
+				foundBegin = !foundBegin
+			}
+			continue
+		}
+		if (*nodes)[i-1].tok == token.QUO && (*nodes)[i].tok == token.RPAREN {
+			foundBegin = false
+			p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+			(*nodes)[begin-1].tok, (*nodes)[begin-1].b = token.IDENT, []byte("intrinsic.ARRAY")
+			(*nodes)[begin].tok, (*nodes)[begin].b = token.LPAREN, []byte("(")
+			(*nodes)[i-1].tok, (*nodes)[i-1].b = token.RPAREN, []byte(")")
+			*nodes = append((*nodes)[:i], (*nodes)[i+1:]...)
+			i--
+		}
+	}
+}
+
+// keywordArguments return names of arguments of intrinsic procedure
+func keywordArguments(name string) ([]string, bool) {
+	if keywords, ok := arrayFunctions[name]; ok {
+		return keywords, true
+	}
+	s, ok := systemFunctions[name]
+	return s.keywords, ok && len(s.keywords) > 0
+}
+
+// fixKeywordArguments change keyword arguments of array and system
+// intrinsic procedures to positional arguments, absent arguments are nil
+//
+// Example:
+//  SUM ( A , MASK = L )              - SUM ( A , nil , L )
+//  SYSTEM_CLOCK ( COUNT_RATE = R )   - SYSTEM_CLOCK ( nil , R )
+func (p *parser) fixKeywordArguments(nodes *[]node) {
+	for i := len(*nodes) - 2; i >= 0; i-- {
+		keywords, ok := keywordArguments(strings.ToUpper(string((*nodes)[i].b)))
+		if !ok || (*nodes)[i].tok != token.IDENT || (*nodes)[i+1].tok != token.LPAREN ||
+			p.isVariable(string((*nodes)[i].b)) {
+			continue
+		}
+
+		// arguments inside parens
+		var args [][]node
+		var keyword bool
+		level, start, end := 0, i+2, -1
+		for j := i + 1; j < len(*nodes) && end < 0; j++ {
+			switch (*nodes)[j].tok {
+			case token.LPAREN:
+				level++
+			case token.RPAREN:
+				level--
+				if level == 0 {
+					args, end = append(args, (*nodes)[start:j]), j
+				}
+			case token.COMMA:
+				if level == 1 {
+					args, start = append(args, (*nodes)[start:j]), j+1
+				}
+			case token.ASSIGN:
+				keyword = keyword || level == 1
+			}
+		}
+		if end < 0 || !keyword {
+			continue
+		}
+
+		var sorted [][]node
+		for pos, arg := range args {
+			if len(arg) > 2 && arg[0].tok == token.IDENT && arg[1].tok == token.ASSIGN {
+				pos = -1
+				for k := range keywords {
+					if strings.EqualFold(string(arg[0].b), keywords[k]) {
+						pos = k
+					}
+				}
+				if pos < 0 {
+					p.addError(fmt.Sprintf("Not valid keyword %s of %s",
+						string(arg[0].b), string((*nodes)[i].b)))
+					return
+				}
+				arg = arg[2:]
+			}
+			for len(sorted) <= pos {
+				sorted = append(sorted, []node{{tok: token.IDENT, b: []byte("nil")}})
+			}
+			sorted[pos] = arg
+		}
+
+		comb := append([]node{}, (*nodes)[:i+2]...)
+		for k := range sorted {
+			if k > 0 {
+				comb = append(comb, node{tok: token.COMMA, b: []byte(",")})
+			}
+			comb = append(comb, sorted[k]...)
+		}
+		*nodes = append(comb, (*nodes)[end:]...)
+	}
+}
+
+// arrayArgument is argument of array intrinsic function
+type arrayArgument struct {
+	expr   goast.Expr
+	typ    string // type of elements
+	rank   int
+	absent bool
+}
+
+// arrayIntrinsic change call of array intrinsic function to call of
+// function of package intrinsic. Type of result with rank is type
+// parameter of function, absent DIM is 0 and other absent arguments
+// are nil.
+//
+// Example:
+//  SUM(A)               - intrinsic.SUM[float64]((*A), 0, nil)
+//  SUM(A, DIM = 2)      - intrinsic.SUM[[]float64]((*A), 2, nil)
+//  MAXLOC(A, MASK = L)  - intrinsic.MAXLOC[[]int]((*A), 0, (*L))
+//  UBOUND(K, 1)         - intrinsic.UBOUND[int]((*K), []int{-2}, 1)
+func arrayIntrinsic(p *parser, f *goast.CallExpr) {
+	id, ok := f.Fun.(*goast.Ident)
+	if !ok || id.Name != strings.ToUpper(id.Name) {
+		return
+	}
+	name := id.Name
+
+	args := make([]arrayArgument, len(arrayFunctions[name]))
+	if len(f.Args) == 0 || len(args) < len(f.Args) {
+		p.addError(fmt.Sprintf("Not valid amount of arguments for %s: %d", name, len(f.Args)))
+		return
+	}
+	for i := range args {
+		if len(f.Args) <= i {
+			args[i].absent = true
+			continue
+		}
+		args[i].expr, args[i].typ = p.intrinsicArgument(f.Args[i])
+		if id, ok := removeParen(args[i].expr).(*goast.Ident); ok && id.Name == "nil" {
+			args[i].absent = true
+		}
+		args[i].rank = p.rankOf(args[i].expr)
+		args[i].expr = p.elementalArray(args[i].expr, args[i].rank)
+	}
+	a := args[0]
+	if (a.rank == 0 && name != "MERGE" && name != "SPREAD") ||
+		((name == "MATMUL" || name == "UNPACK") && args[1].rank == 0) {
+		p.addError(fmt.Sprintf("Cannot find rank of array argument of %s", name))
+		return
+	}
+
+	var result string // type parameter with type of result
+	switch name {
+	case "SUM", "PRODUCT", "MAXVAL", "MINVAL", "MAXLOC", "MINLOC":
+		dim, mask := args[1], args[2]
+		if dim.typ == "bool" && mask.absent {
+			// SUM(A, L)
+			dim, mask = mask, dim
+		}
+		result = a.typ
+		if name == "MAXLOC" || name == "MINLOC" {
+			result = "[]int"
+		}
+		if !dim.absent {
+			result = strings.Repeat("[]", a.rank-1) + strings.TrimPrefix(result, "[]")
+		}
+		f.Args = []goast.Expr{a.expr, dimArgument(dim), nilArgument(mask)}
+	case "COUNT", "ANY", "ALL":
+		result = "bool"
+		if name == "COUNT" {
+			result = "int"
+		}
+		if !args[1].absent {
+			result = strings.Repeat("[]", a.rank-1) + result
+		}
+		f.Args = []goast.Expr{a.expr, dimArgument(args[1])}
+	case "DOT_PRODUCT":
+		result = a.typ
+		f.Args = []goast.Expr{a.expr, args[1].expr}
+	case "MATMUL":
+		result = strings.Repeat("[]", a.rank+args[1].rank-2) + a.typ
+		f.Args = []goast.Expr{a.expr, args[1].expr}
+	case "TRANSPOSE":
+		result = "[][]" + a.typ
+		f.Args = []goast.Expr{a.expr}
+	case "SIZE":
+		f.Args = []goast.Expr{a.expr, dimArgument(args[1])}
+	case "SHAPE":
+		f.Args = []goast.Expr{a.expr}
+	case "LBOUND", "UBOUND":
+		result = "[]int"
+		if !args[1].absent {
+			result = "int"
+		}
+		f.Args = []goast.Expr{a.expr, p.lowerBounds(a.expr), dimArgument(args[1])}
+	case "RESHAPE":
+		rank, ok := p.extentOf(args[1].expr)
+		if !ok {
+			p.addError("Cannot find rank of result of RESHAPE")
+			return
+		}
+		result = strings.Repeat("[]", rank) + a.typ
+		f.Args = []goast.Expr{a.expr, args[1].expr, nilArgument(args[2])}
+	case "SPREAD":
+		result = strings.Repeat("[]", a.rank+1) + a.typ
+		f.Args = []goast.Expr{a.expr, dimArgument(args[1]), dimArgument(args[2])}
+	case "PACK":
+		result = "[]" + a.typ
+		f.Args = []goast.Expr{a.expr, args[1].expr, nilArgument(args[2])}
+	case "UNPACK":
+		result = strings.Repeat("[]", args[1].rank) + a.typ
+		f.Args = []goast.Expr{a.expr, args[1].expr, args[2].expr}
+	case "CSHIFT", "EOSHIFT":
+		result = strings.Repeat("[]", a.rank) + a.typ
+		dim := args[len(args)-1]
+		if dim.absent {
+			dim = arrayArgument{expr: goast.NewIdent("1")}
+		}
+		f.Args = []goast.Expr{a.expr, dimArgument(args[1])}
+		if name == "EOSHIFT" {
+			f.Args = append(f.Args, nilArgument(args[2]))
+		}
+		f.Args = append(f.Args, dimArgument(dim))
+	case "MERGE":
+		rank, typ := 0, a.typ
+		for _, arg := range args {
+			if rank < arg.rank {
+				rank = arg.rank
+			}
+		}
+		if typ == "" {
+			typ = args[1].typ
+		}
+		result = strings.Repeat("[]", rank) + typ
+		f.Args = []goast.Expr{a.expr, args[1].expr, args[2].expr}
+	}
+
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	f.Fun = &goast.SelectorExpr{
+		X:   goast.NewIdent("intrinsic"),
+		Sel: goast.NewIdent(name),
+	}
+	if result != "" {
+		f.Fun = &goast.IndexExpr{X: f.Fun, Index: goast.NewIdent(result)}
+	}
+}
+
+// dimArgument return INTEGER argument as int or 0 for absent argument
+func dimArgument(arg arrayArgument) goast.Expr {
+	if arg.absent {
+		return goast.NewIdent("0")
+	}
+	if arg.typ == "" || isConstant(arg.expr) {
+		return arg.expr
+	}
+	return convert(arg.expr, arg.typ, "int")
+}
+
+// nilArgument return argument or nil for absent argument
+func nilArgument(arg arrayArgument) goast.Expr {
+	if arg.absent {
+		return goast.NewIdent("nil")
+	}
+	return arg.expr
+}
+
+// arrayType return type of array variable
+func (p *parser) arrayType(e goast.Expr) (typ goType, ok bool) {
+	switch e := removeParen(e).(type) {
+	case *goast.StarExpr:
+		return p.arrayType(e.X)
+	case *goast.Ident:
+		name := strings.TrimLeft(strings.TrimRight(e.Name, ")"), "(*")
+		if v, ok := p.initVars.get(name); ok {
+			return v.typ, v.typ.isArray()
+		}
+		typ, ok = p.arguments[name]
+		return typ, ok && typ.isArray()
+	}
+	return
+}
+
+// rankOf return rank of array value, rank of other values is 0
+func (p *parser) rankOf(e goast.Expr) int {
+	if typ, ok := p.arrayType(e); ok {
+		return len(typ.arrayNode)
+	}
+	if rank, ok := p.sectionRank(e); ok {
+		return rank
+	}
+	var call *goast.CallExpr
+	switch x := removeParen(e).(type) {
+	case *goast.BinaryExpr:
+		// elemental operation with rank of array operand
+		if rank := p.rankOf(x.X); rank > 0 {
+			return rank
+		}
+		return p.rankOf(x.Y)
+	case *goast.UnaryExpr:
+		return p.rankOf(x.X)
+	case *goast.CallExpr:
+		call = x
+	default:
+		return 0
+	}
+	switch fun := call.Fun.(type) {
+	case *goast.IndexExpr:
+		// intrinsic.SUM[[]float64]
+		if id, ok := fun.Index.(*goast.Ident); ok {
+			return strings.Count(id.Name, "[]")
+		}
+	case *goast.SelectorExpr:
+		switch fun.Sel.Name {
+		case "ARRAY", "SHAPE":
+			return 1
+		}
+	}
+	return 0
+}
+
+// elementalArray change elemental operation with array operands to
+// array of results for each element. Array operands are evaluated
+// once before loops, shape of result is shape of first array operand.
+//
+// Example:
+//  V .GT. 2  - func() []bool {
+//                  a0 := (*V)
+//                  r := make([]bool, len(a0))
+//                  for i0 := range r {
+//                      r[i0] = a0[i0] > 2
+//                  }
+//                  return r
+//              }()
+func (p *parser) elementalArray(e goast.Expr, rank int) goast.Expr {
+	switch removeParen(e).(type) {
+	case *goast.BinaryExpr, *goast.UnaryExpr:
+	default:
+		return e
+	}
+	typ := p.typeOf(e)
+	if typ == "" {
+		p.addError("Cannot find type of elements of array expression")
+		return e
+	}
+
+	// index return expression with subscripts of loops before level
+	index := func(x goast.Expr, level int) goast.Expr {
+		for i := 0; i < level; i++ {
+			x = &goast.IndexExpr{X: x, Index: goast.NewIdent(fmt.Sprintf("i%d", i))}
+		}
+		return x
+	}
+	var body []goast.Stmt
+	var element func(x goast.Expr) goast.Expr
+	element = func(x goast.Expr) goast.Expr {
+		switch x := x.(type) {
+		case *goast.ParenExpr:
+			return &goast.ParenExpr{X: element(x.X)}
+		case *goast.BinaryExpr:
+			return &goast.BinaryExpr{X: element(x.X), Op: x.Op, Y: element(x.Y)}
+		case *goast.UnaryExpr:
+			return &goast.UnaryExpr{Op: x.Op, X: element(x.X)}
+		}
+		if r := p.rankOf(x); r != rank {
+			if r > 0 {
+				p.addError(fmt.Sprintf("Not conformable arrays of rank %d and %d", rank, r))
+			}
+			return x
+		}
+		name := goast.NewIdent(fmt.Sprintf("a%d", len(body)))
+		body = append(body, &goast.AssignStmt{
+			Lhs: []goast.Expr{name},
+			Tok: token.DEFINE,
+			Rhs: []goast.Expr{x},
+		})
+		return index(name, rank)
+	}
+	value := element(e)
+	if len(body) == 0 {
+		return e
+	}
+
+	// loops from outer slice to elements
+	r := goast.NewIdent("r")
+	var loop func(level int) goast.Stmt
+	loop = func(level int) goast.Stmt {
+		var stmt goast.Stmt = &goast.AssignStmt{
+			Lhs: []goast.Expr{index(r, level+1)},
+			Tok: token.ASSIGN,
+			Rhs: []goast.Expr{value},
+		}
+		if level+1 < rank {
+			stmt = &goast.BlockStmt{List: []goast.Stmt{
+				&goast.AssignStmt{
+					Lhs: []goast.Expr{index(r, level+1)},
+					Tok: token.ASSIGN,
+					Rhs: []goast.Expr{p.makeArray(typ, rank-level-1, level+1)},
+				},
+				loop(level + 1),
+			}}
+		}
+		block, ok := stmt.(*goast.BlockStmt)
+		if !ok {
+			block = &goast.BlockStmt{List: []goast.Stmt{stmt}}
+		}
+		return &goast.RangeStmt{
+			Key:  goast.NewIdent(fmt.Sprintf("i%d", level)),
+			Tok:  token.DEFINE,
+			X:    index(r, level),
+			Body: block,
+		}
+	}
+	body = append(body,
+		&goast.AssignStmt{
+			Lhs: []goast.Expr{r},
+			Tok: token.DEFINE,
+			Rhs: []goast.Expr{p.makeArray(typ, rank, 0)},
+		},
+		loop(0),
+		&goast.ReturnStmt{Results: []goast.Expr{r}},
+	)
+	return &goast.CallExpr{Fun: &goast.FuncLit{
+		Type: &goast.FuncType{
+			Params: &goast.FieldList{},
+			Results: &goast.FieldList{List: []*goast.Field{{
+				Type: goast.NewIdent(strings.Repeat("[]", rank) + typ),
+			}}},
+		},
+		Body: &goast.BlockStmt{List: body},
+	}}
+}
+
+// makeArray return allocation of slice with rank and length of first
+// array operand a0 of elemental operation inside loops before level
+func (p *parser) makeArray(typ string, rank, level int) goast.Expr {
+	var length goast.Expr = goast.NewIdent("a0")
+	for i := 0; i < level; i++ {
+		length = &goast.IndexExpr{X: length, Index: goast.NewIdent(fmt.Sprintf("i%d", i))}
+	}
+	return &goast.CallExpr{
+		Fun: goast.NewIdent("make"),
+		Args: []goast.Expr{
+			goast.NewIdent(strings.Repeat("[]", rank) + typ),
+			&goast.CallExpr{Fun: goast.NewIdent("len"), Args: []goast.Expr{length}},
+		},
+	}
+}
+
+// sectionRank return rank of array section. Section is not found,
+// if subscript triplet is not last part of Go slice, because first
+// index of array is outer slice.
+//
+// Example:
+//  A(2:N)     - (*A)[(2)-(1):N]           - rank 1
+//  B(J, 1:2)  - (*B)[(*J)-(1)][(1)-(1):2] - rank 1
+//  B(:, :)    - (*B)[:][:]                - rank 2
+//  B(1:2, J)  - (*B)[(1)-(1):2][(*J)-(1)] - not found
+func (p *parser) sectionRank(e goast.Expr) (rank int, ok bool) {
+	var subscripts int
+	partial := false // triplet with bounds
+	for {
+		switch x := removeParen(e).(type) {
+		case *goast.IndexExpr:
+			subscripts++
+			e = x.X
+			continue
+		case *goast.SliceExpr:
+			if subscripts > 0 || partial {
+				return 0, false
+			}
+			partial = x.Low != nil || x.High != nil
+			rank++
+			e = x.X
+			continue
+		}
+		break
+	}
+	typ, isArray := p.arrayType(e)
+	if !isArray || rank == 0 || len(typ.arrayNode) != rank+subscripts {
+		return 0, false
+	}
+	return rank, true
+}
+
+// extentOf return size of vector, if size is constant
+func (p *parser) extentOf(e goast.Expr) (size int, ok bool) {
+	if typ, ok := p.arrayType(e); ok && len(typ.arrayNode) == 1 {
+		size, err := strconv.Atoi(nodesToString(typ.arrayNode[0]))
+		return size, err == nil
+	}
+	call, ok := removeParen(e).(*goast.CallExpr)
+	if !ok {
+		return
+	}
+	if sel, ok := call.Fun.(*goast.SelectorExpr); ok {
+		switch sel.Sel.Name {
+		case "ARRAY":
+			return len(call.Args), true
+		case "SHAPE":
+			return p.rankOf(call.Args[0]), true
+		}
+	}
+	return
+}
+
+// lowerBounds return lower bounds of array variable or nil, if all
+// lower bounds are 1
+//
+// Example:
+//  INTEGER K(-2:2, 4)  - []int{-2, 1}
+func (p *parser) lowerBounds(e goast.Expr) goast.Expr {
+	typ, ok := p.arrayType(e)
+	if !ok {
+		return goast.NewIdent("nil")
+	}
+	bounds := &goast.CompositeLit{Type: goast.NewIdent("[]int")}
+	var found bool
+	for _, dim := range typ.arrayNode {
+		var lower goast.Expr = goast.NewIdent("1")
+		for i := range dim {
+			if dim[i].tok == token.COLON {
+				lower, found = p.parseExprNodes(dim[:i]), true
+				break
+			}
+		}
+		bounds.Elts = append(bounds.Elts, lower)
+	}
+	if !found {
+		return goast.NewIdent("nil")
+	}
+	return bounds
+}
@@ -0,0 +1,104 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArrayIntrinsic(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE ARR(A, V, X)
+      DOUBLE PRECISION A(3, 4), V(*), X
+      DOUBLE PRECISION B(4, 3), W(4)
+      INTEGER K(-2:2), J(2), N
+      LOGICAL L(3, 4)
+      W = SUM(A, DIM = 1)
+      X = SUM(A, MASK = L) + MAXVAL(A, L) + COUNT(L)
+      J = MINLOC(A, DIM = 1, MASK = L)
+      B = MATMUL(TRANSPOSE(A), RESHAPE(A, (/ 3, 4 /)))
+      N = LBOUND(K, 1) + UBOUND(K, DIM = 1) + SIZE(A)
+      A = EOSHIFT(A, 1, DIM = 2)
+      CALL FOO((/ 1, 2 /))
+      N = MAXLOC(V((N+1):(2*N)), 1) + MINLOC(A(2, 2:4), DIM = 1)
+      W = SUM(A(:, :), 1, L(:, :))
+      END
+`)
+	for _, s := range []string{
+		`(*W) = intrinsic.SUM[[]float64]((*(A)), 1, nil)`,
+		`(*(X)) = intrinsic.SUM[float64]((*(A)), 0, (*L)) + intrinsic.MAXVAL[float64]((*(A)), 0, (*L)) + float64(intrinsic.COUNT[int]((*L), 0))`,
+		`(*J) = intrinsic.MINLOC[[]int]((*(A)), 1, (*L))`,
+		`(*B) = intrinsic.MATMUL[[][]float64](intrinsic.TRANSPOSE[[][]float64]((*(A))), intrinsic.RESHAPE[[][]float64]((*(A)), intrinsic.ARRAY(3, 4), nil))`,
+		`(*N) = intrinsic.LBOUND[int]((*K), []int{-2}, 1) + intrinsic.UBOUND[int]((*K), []int{-2}, 1) + intrinsic.SIZE((*(A)), 0)`,
+		`(*(A)) = intrinsic.EOSHIFT[[][]float64]((*(A)), 1, nil, 2)`,
+		`y := intrinsic.ARRAY(1, 2)`,
+		`(*N) = intrinsic.MAXLOC[int]((*(V))[((*N)+1)-(1):(2*(*N))], 1, nil) + intrinsic.MINLOC[int]((*(A))[2-(1)][(2)-(1):4], 1, nil)`,
+		`(*W) = intrinsic.SUM[[]float64]((*(A))[:][:], 1, (*L)[:][:])`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestArrayIntrinsicSection(t *testing.T) {
+	// section with subscript triplet before subscript is not slice of Go
+	_, errs := Parse([]byte(`
+      SUBROUTINE ARR(A, X)
+      DOUBLE PRECISION A(3, 4), X
+      X = SUM(A(1:2, 3), 1)
+      END
+`), "main")
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "Cannot find rank of array argument of SUM") {
+		t.Errorf("Not valid errors: %v", errs)
+	}
+}
+
+func TestArrayIntrinsicElemental(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE ARR(V, A, L, N, X)
+      INTEGER V(5), N
+      DOUBLE PRECISION A(3, 4), X, B(3, 4)
+      LOGICAL L
+      N = COUNT(V .GT. 2) + COUNT(.NOT. (V .GT. 2))
+      L = ANY(V .EQ. 3) .AND. ALL(A .GE. 0.0D0)
+      X = SUM(A, MASK = A .GT. 0)
+      X = SUM(A(1, :), MASK = A(2, :) .GT. 0)
+      B = MERGE(A, -A, A .GT. 0)
+      X = MERGE(X, 1.0D0, L)
+      END
+`)
+	for _, s := range []string{
+		`(*(N)) = intrinsic.COUNT[int](func() []bool {
+		a0 := *(V)
+		r := make([]bool, len(a0))
+		for i0 := range r {
+			r[i0] = (a0[i0]) > 2
+		}
+		return r
+	}(), 0)`,
+		`r[i0] = !((a0[i0]) > 2)`,
+		`intrinsic.ANY[bool](func() []bool {`,
+		`intrinsic.ALL[bool](func() [][]bool {
+		a0 := *(A)
+		r := make([][]bool, len(a0))
+		for i0 := range r {
+			r[i0] = make([]bool, len(a0[i0]))
+			for i1 := range r[i0] {
+				r[i0][i1] = (a0[i0][i1]) >= 0.0e0
+			}
+		}
+		return r
+	}(), 0)`,
+		`(*(X)) = intrinsic.SUM[float64]((*(A)), 0, func() [][]bool {`,
+		`(*(X)) = intrinsic.SUM[float64]((*(A))[1-(1)][:], 0, func() []bool {
+		a0 := (*(A))[2-(1)][:]`,
+		`(*B) = intrinsic.MERGE[[][]float64]((*(A)), func() [][]float64 {`,
+		`r[i0][i1] = -(a0[i0][i1])`,
+		`(*(X)) = intrinsic.MERGE[float64]((*(X)), 1.0e0, (*(L)))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+	vetFiles(t, []string{out})
+}
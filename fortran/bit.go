@@ -0,0 +1,61 @@
+package fortran
+
+import (
+	"fmt"
+	goast "go/ast"
+)
+
+func init() {
+	for _, group := range []struct {
+		names    []string
+		args     []string
+		optional int
+		result   string
+	}{
+		{names: []string{"IAND", "IOR", "IEOR"}, args: []string{any, any}, result: any},
+		{names: []string{"NOT"}, args: []string{any}, result: any},
+		{names: []string{"ISHFT", "IBSET", "IBCLR"}, args: []string{any, "int"}, result: any},
+		{names: []string{"ISHFTC"}, args: []string{any, "int", "int"}, optional: 1, result: any},
+		{names: []string{"BTEST"}, args: []string{any, "int"}, result: "bool"},
+		{names: []string{"IBITS"}, args: []string{any, "int", "int"}, result: any},
+		{names: []string{"BIT_SIZE"}, args: []string{any}, result: "int"},
+	} {
+		for _, name := range group.names {
+			specificFunctions[name] = specific{
+				args:     group.args,
+				optional: group.optional,
+				result:   group.result,
+			}
+			intrinsicFunction[name] = numericIntrinsic
+		}
+	}
+	intrinsicFunction["MVBITS"] = mvbits
+}
+
+// mvbits change call of subroutine MVBITS. Argument TO is changed, so
+// it is pointer.
+//
+// Example:
+//  CALL MVBITS(I, 0, 3, J, 4)  - intrinsic.MVBITS((*I), 0, 3, J, 4)
+func mvbits(p *parser, f *goast.CallExpr) {
+	if len(f.Args) != 5 {
+		p.addError(fmt.Sprintf("Not valid amount of arguments for MVBITS: %d", len(f.Args)))
+		return
+	}
+	for i := range f.Args {
+		if i == 3 {
+			f.Args[i] = pointerArgument(f.Args[i])
+			continue
+		}
+		arg, typ := p.intrinsicArgument(f.Args[i])
+		if i > 0 && typ != "" && !isConstant(arg) {
+			arg = convert(arg, typ, "int")
+		}
+		f.Args[i] = arg
+	}
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	f.Fun = &goast.SelectorExpr{
+		X:   goast.NewIdent("intrinsic"),
+		Sel: goast.NewIdent("MVBITS"),
+	}
+}
@@ -0,0 +1,32 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBitIntrinsic(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE BIT(N, A)
+      INTEGER N, A(2)
+      INTEGER*2 I
+      LOGICAL L
+      I = ISHFT(I, -N) + IAND(I, 255)
+      L = BTEST(N, 3) .AND. BTEST(I, 1)
+      N = ISHFTC(N, 1, 8) + IBITS(N, 2, I)
+      CALL MVBITS(N, 0, 3, A(2), I)
+      CALL MVBITS(I, 0, 3, I, 4)
+      END
+`)
+	for _, s := range []string{
+		`(*I) = intrinsic.ISHFT((*I), -(*(N))) + intrinsic.IAND((*I), 255)`,
+		`(*L) = intrinsic.BTEST((*(N)), 3) && intrinsic.BTEST((*I), 1)`,
+		`(*(N)) = intrinsic.ISHFTC((*(N)), 1, 8) + intrinsic.IBITS((*(N)), 2, int((*I)))`,
+		`intrinsic.MVBITS((*(N)), 0, 3, &(*(A))[2-(1)], int((*I)))`,
+		`intrinsic.MVBITS((*I), 0, 3, I, 4)`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
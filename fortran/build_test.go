@@ -0,0 +1,106 @@
+package fortran
+
+import (
+	goast "go/ast"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBuildPasses check that Go code after changes of each pass is
+// valid. Passes are same as flags of f4go.
+func TestBuildPasses(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not found")
+	}
+	srcs := []string{`
+      PROGRAM MAIN
+      INTEGER N, INFO, A(5)
+      REAL X, Y, C, S
+      COMMON /BLK/ N
+      N = 5
+      CALL SETA(N, A, INFO)
+      X = 3.0
+      Y = 4.0
+      CALL ROTG(X, Y, C, S)
+      WRITE(*, '(5I3, 2F6.2)') A, C, S
+      WRITE(*, *) ISUM(A)
+      END
+
+      INTEGER FUNCTION ISUM(A)
+      INTEGER A(*), I, N
+      COMMON /BLK/ N
+      ISUM = 0
+      DO I = 1, N
+         ISUM = ISUM + A(I)
+      END DO
+      END
+`, `
+      SUBROUTINE SETA(N, A, INFO)
+      INTEGER N, A(*), INFO, I
+      INFO = 0
+      IF (N .LT. 0) THEN
+         INFO = -1
+         CALL XERBLA('SETA  ', 1)
+         RETURN
+      END IF
+      DO I = 1, N
+         A(I) = I * N
+      END DO
+      END
+
+      SUBROUTINE ROTG(SA, SB, C, S)
+      REAL SA, SB, C, S, R
+      R = SQRT(SA**2 + SB**2)
+      C = SA / R
+      S = SB / R
+      SA = R
+      END
+
+      SUBROUTINE XERBLA(SRNAME, INFO)
+      CHARACTER*(*) SRNAME
+      INTEGER INFO
+      WRITE(*, '(A, I2)') SRNAME, INFO
+      STOP
+      END
+`}
+	passes := map[string]func([]*goast.File){
+		"-e": ErrorReturns,
+		"-s": Simplify,
+		"-w": Wrappers,
+		"-r": Reentrant,
+	}
+	for _, flags := range []string{"", "-e", "-s", "-w", "-r", "-e -s -w -r"} {
+		t.Run(flags, func(t *testing.T) {
+			var ps []func([]*goast.File)
+			for _, flag := range strings.Fields(flags) {
+				ps = append(ps, passes[flag])
+			}
+			vetFiles(t, parseFiles(t, srcs, ps...))
+		})
+	}
+}
+
+// vetFiles check Go sources of one package by go vet
+func vetFiles(t *testing.T, out []string) {
+	// package is in module for import of intrinsic
+	dir, err := ioutil.TempDir(filepath.Join("..", "testdata"), "vet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for i := range out {
+		name := filepath.Join(dir, string(rune('a'+i))+".go")
+		if err := ioutil.WriteFile(name, []byte(out[i]), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = dir
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Not valid Go code: %v\n%s\n%s", err, b, out)
+	}
+}
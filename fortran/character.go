@@ -0,0 +1,202 @@
+package fortran
+
+import (
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	for _, group := range []struct {
+		names    []string
+		args     []string
+		optional int
+		result   string
+	}{
+		{names: []string{"ICHAR", "IACHAR"}, args: []string{"byte"}, result: "int"},
+		{names: []string{"CHAR", "ACHAR"}, args: []string{"int"}, result: "byte"},
+		{names: []string{"LGE", "LGT", "LLE", "LLT"},
+			args: []string{"[]byte", "[]byte"}, result: "bool"},
+		{names: []string{"INDEX", "SCAN", "VERIFY"},
+			args: []string{"[]byte", "[]byte", "bool"}, optional: 1, result: "int"},
+		{names: []string{"LEN_TRIM"}, args: []string{"[]byte"}, result: "int"},
+		{names: []string{"TRIM", "ADJUSTL", "ADJUSTR"},
+			args: []string{"[]byte"}, result: "[]byte"},
+		{names: []string{"REPEAT"}, args: []string{"[]byte", "int"}, result: "[]byte"},
+	} {
+		for _, name := range group.names {
+			specificFunctions[name] = specific{
+				args:     group.args,
+				optional: group.optional,
+				result:   group.result,
+			}
+			intrinsicFunction[name] = numericIntrinsic
+		}
+	}
+}
+
+// characterComparison change relational operation with CHARACTER
+// values to comparison with blank padding
+//
+// Example:
+//  S .EQ. 'ABC'      - intrinsic.COMPARE((*S), []byte("ABC")) == 0
+//  S .LT. CHAR(I)    - intrinsic.COMPARE((*S), []byte{intrinsic.CHAR((*I))}) < 0
+func (p *parser) characterComparison(be *goast.BinaryExpr) {
+	switch be.Op {
+	case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ:
+	default:
+		return
+	}
+	x, y := p.typeOf(be.X), p.typeOf(be.Y)
+	if x != "[]byte" && y != "[]byte" {
+		return
+	}
+	if (x != "[]byte" && x != "byte") || (y != "[]byte" && y != "byte") {
+		return
+	}
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	be.X = &goast.CallExpr{
+		Fun: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent("COMPARE"),
+		},
+		Args: []goast.Expr{
+			characterValue(be.X, x),
+			characterValue(be.Y, y),
+		},
+	}
+	be.Y = goast.NewIdent("0")
+}
+
+// isCharacterCall return true for call with CHARACTER arguments
+//
+// Example:
+//  (*S).Set(...)
+//  intrinsic.CONCAT(...)
+func isCharacterCall(call *goast.CallExpr) bool {
+	if isSetCall(call) {
+		return true
+	}
+	sel, ok := call.Fun.(*goast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*goast.Ident)
+	return ok && x.Name == "intrinsic" && sel.Sel.Name == "CONCAT"
+}
+
+// characterValue return CHARACTER value as []byte
+func characterValue(e goast.Expr, typ string) goast.Expr {
+	if lit, ok := e.(*goast.BasicLit); ok && lit.Kind == token.STRING {
+		return goast.NewIdent("[]byte(" + lit.Value + ")")
+	}
+	if lit, ok := e.(*goast.BasicLit); ok && lit.Kind == token.CHAR {
+		return goast.NewIdent("[]byte{" + lit.Value + "}")
+	}
+	return convert(e, typ, "[]byte")
+}
+
+// convert return expression with conversion of type
+//
+// Example:
+//  byte to []byte  - []byte{x}
+//  []byte to byte  - x[0]
+//  complex to real - real(x)
+//  int to float64  - float64(x)
+func convert(e goast.Expr, from, to string) goast.Expr {
+	switch {
+	case from == to:
+		return e
+	case from == "byte" && to == "[]byte":
+		return &goast.CompositeLit{
+			Type: goast.NewIdent("[]byte"),
+			Elts: []goast.Expr{e},
+		}
+	case from == "[]byte" && to == "byte":
+		return &goast.IndexExpr{X: e, Index: goast.NewIdent("0")}
+	case kind(from) == "complex128" && to != "complex128":
+		to = "real"
+	case strings.HasPrefix(to, "[]"):
+		return e
+	}
+	return &goast.CallExpr{Fun: goast.NewIdent(to), Args: []goast.Expr{e}}
+}
+
+// initializeCharacter return initialization of CHARACTER variable
+//
+// Example:
+//  CHARACTER * 8 S     - S := intrinsic.NewCharacter(8)
+//  CHARACTER * 8 A(3)  - A := func() *[]intrinsic.Character {
+//                          arr := intrinsic.NewCharacters(8, 3)
+//                          return &arr
+//                        }()
+func (p *parser) initializeCharacter(name string, goT goType, assign bool) []goast.Stmt {
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	length := p.characterLength(name, goT)
+
+	// value of array with dimension col
+	var value func(col int) string
+	value = func(col int) string {
+		size, _ := p.getSize(name, col)
+		if col == len(goT.arrayNode)-1 {
+			return fmt.Sprintf("intrinsic.NewCharacters(%s, %d)", length, size)
+		}
+		typ := strings.Repeat("[]", len(goT.arrayNode)-col) + character
+		return fmt.Sprintf("func() %s { arr := make(%s, %d); "+
+			"for u := range arr { arr[u] = %s }; return arr }()",
+			typ, typ, size, value(col+1))
+	}
+
+	src := fmt.Sprintf("package main\nfunc main() {\n\tMATRIX := intrinsic.NewCharacter(%s)\n}", length)
+	if goT.isArray() {
+		src = fmt.Sprintf("package main\nfunc main() {\n\tMATRIX := func() *%s%s { arr := %s; return &arr }()\n}",
+			strings.Repeat("[]", len(goT.arrayNode)), character, value(0))
+	}
+	f, err := goparser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		panic(fmt.Errorf("Error: %v\nSource:\n%s\npos=%s",
+			err, src, goT.arrayNode))
+	}
+	goast.Walk(replacer{from: "MATRIX", to: name}, f)
+
+	list := f.Decls[0].(*goast.FuncDecl).Body.List
+	if assign {
+		list[0].(*goast.AssignStmt).Tok = token.ASSIGN
+	}
+	return list
+}
+
+// characterLength return length of CHARACTER variable as Go expression.
+// Length of CHARACTER * ( * ) constant is length of value.
+//
+// Example:
+//  CHARACTER * 8          - 8
+//  CHARACTER * ( N + 1 )  - (*N) + 1
+//  CHARACTER * ( * ) S    - 3, if PARAMETER ( S = 'ABC' )
+func (p *parser) characterLength(name string, goT goType) string {
+	if len(goT.length) == 0 {
+		if c, ok := p.constants[strings.ToUpper(name)]; ok &&
+			len(c) == 1 && c[0].tok == token.STRING {
+			if s, err := strconv.Unquote(string(c[0].b)); err == nil {
+				return strconv.Itoa(len(s))
+			}
+		}
+		return "1"
+	}
+	var length string
+	for _, n := range goT.length {
+		if n.tok != token.IDENT {
+			length += string(n.b)
+			continue
+		}
+		if c, ok := p.constants[strings.ToUpper(string(n.b))]; ok {
+			length += "(" + nodesToString(c) + ")"
+			continue
+		}
+		length += "(*" + strings.ToUpper(string(n.b)) + ")"
+	}
+	return length
+}
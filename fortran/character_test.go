@@ -0,0 +1,72 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCharacterIntrinsic(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE CH(S, C, T)
+      CHARACTER*8 S
+      CHARACTER C
+      CHARACTER*(*) T
+      INTEGER I
+      LOGICAL L
+      IF (S .EQ. 'ABC') I = 1
+      IF (S .NE. T .AND. C .EQ. 'N') I = 2
+      L = T .LT. C
+      I = ICHAR(C) + INDEX(S, 'B') + INDEX(S, T, .TRUE.)
+      C = CHAR(I)
+      L = LGE(S, T) .OR. LLT(C, 'AB')
+      I = LEN_TRIM(ADJUSTL(S))
+      IF (TRIM(S) .EQ. REPEAT(T, 2)) I = SCAN(S, 'XY')
+      END
+`)
+	for _, s := range []string{
+		`if intrinsic.COMPARE((*(S)), []byte("ABC")) == 0 {`,
+		`if intrinsic.COMPARE((*(S)), (*(T))) != 0 && intrinsic.COMPARE((*(C)), []byte("N")) == 0 {`,
+		`(*L) = intrinsic.COMPARE((*(T)), (*(C))) < 0`,
+		`(*I) = intrinsic.ICHAR((*(C))[0]) + intrinsic.INDEX((*(S)), []byte("B")) + intrinsic.INDEX((*(S)), (*(T)), (true))`,
+		`(*(C)).Set([]byte{intrinsic.CHAR((*I))})`,
+		`(*L) = intrinsic.LGE((*(S)), (*(T))) || intrinsic.LLT((*(C)), []byte("AB"))`,
+		`(*I) = intrinsic.LEN_TRIM(intrinsic.ADJUSTL((*(S))))`,
+		`if intrinsic.COMPARE(intrinsic.TRIM((*(S))), intrinsic.REPEAT((*(T)), 2)) == 0 {`,
+		`(*I) = intrinsic.SCAN((*(S)), []byte("XY"))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestCharacter(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE CH(X, N)
+      CHARACTER*(*) X
+      INTEGER N
+      CHARACTER*8 S
+      CHARACTER NAME*5, A(3)*4
+      S = 'HELLO'
+      S(2:3) = X(:N) // 'AB'
+      A(2)(N:) = S
+      NAME = A(1)
+      CALL SUB(S(2:4), 'Q')
+      END
+`)
+	for _, s := range []string{
+		`S := intrinsic.NewCharacter(8)`,
+		`NAME := intrinsic.NewCharacter(5)`,
+		`arr := intrinsic.NewCharacters(4, 3)`,
+		`(*S).Set([]byte("HELLO"))`,
+		`(*S)[(2)-1 : 3].Set(intrinsic.CONCAT((*(X))[:(*(N))], []byte("AB")))`,
+		`(*A)[2-(1)][(*(N))-1:].Set((*S))`,
+		`(*NAME).Set((*A)[1-(1)])`,
+		`y := (*S)[(2)-1 : 4]`,
+		`func()*intrinsic.Character{y:=intrinsic.Character("Q");return &y}()`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
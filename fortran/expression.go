@@ -50,7 +50,8 @@ func (p *parser) parseExprNodes(in []node) (expr goast.Expr) {
 	nodes := make([]node, len(in))
 	copy(nodes, in)
 
-	p.fixFakeParen(&nodes)
+	p.fixArrayConstructor(&nodes)
+	p.fixKeywordArguments(&nodes)
 	p.fixArrayVariables(&nodes)
 	if m, ok := p.fixVectorExplode(&nodes); ok {
 		nodes = m
@@ -58,8 +59,8 @@ func (p *parser) parseExprNodes(in []node) (expr goast.Expr) {
 	p.fixDoubleStar(&nodes)
 	p.fixString(&nodes)
 	p.fixComplexValue(&nodes)
-	p.fixIdent(&nodes)
 	p.fixConcatString(&nodes)
+	p.fixIdent(&nodes)
 
 	str := nodesToString(nodes)
 
@@ -87,37 +88,31 @@ func (p *parser) isVariable(name string) bool {
 
 func (p *parser) isArrayVariable(name string) bool {
 	if v, ok := p.initVars.get(name); ok {
-		if v.typ.isArray() || v.typ.baseType == "string" {
+		if v.typ.isArray() || v.typ.isCharacter() {
 			return true
 		}
 	}
 	return false
 }
 
-// change  `(/` and `/)` to `((` and `))`
-func (p *parser) fixFakeParen(nodes *[]node) {
-	var foundBegin bool
-	var begin int
-	for i := 1; i < len(*nodes); i++ {
-		if !foundBegin {
-			if (*nodes)[i-1].tok == token.LPAREN && (*nodes)[i].tok == token.QUO {
-				begin = i
-				foundBegin = !foundBegin
-			}
-			continue
-		}
-		if (*nodes)[i-1].tok == token.QUO && (*nodes)[i].tok == token.RPAREN {
-			foundBegin = false
-			(*nodes)[begin].tok, (*nodes)[begin].b = token.LPAREN, []byte("(")
-			(*nodes)[i-1].tok, (*nodes)[i-1].b = token.RPAREN, []byte(")")
-			continue
-		}
+// isCharacterVariable return true for variable with type CHARACTER or
+// FUNCTION with result CHARACTER
+func (p *parser) isCharacterVariable(name string) bool {
+	v, ok := p.initVars.get(name)
+	if !ok {
+		v, ok = p.initVars.get(name + returnPostfix)
 	}
+	return ok && v.typ.isCharacter()
 }
 
-// fixArrayVariables - change tokens of array
+// fixArrayVariables - change tokens of array and substrings
 // From : ... | NAME | ( | I |   ,   | J | ) | ...
 // To   : ... | NAME | [ | I | ] | [ | J | ] | ...
+//
+// Substring of CHARACTER:
+//  S ( I : J )       - S [ ( I ) - 1 : J ]
+//  S ( : J )         - S [ : J ]
+//  A ( 2 ) ( I : )   - A [ 2 - ( 1 ) ] [ ( I ) - 1 : ]
 func (p *parser) fixArrayVariables(nodes *[]node) {
 	var positions []int
 	// find all arrays
@@ -139,7 +134,7 @@ func (p *parser) fixArrayVariables(nodes *[]node) {
 			if (*nodes)[pos].tok == token.IDENT {
 				var ok bool
 				if v, ok = p.initVars.get(string((*nodes)[pos].b)); ok &&
-					(p.getArrayLen(v.name) > 0 || v.typ.baseType == "string") {
+					(p.getArrayLen(v.name) > 0 || v.typ.isCharacter()) {
 					break
 				}
 			}
@@ -147,56 +142,113 @@ func (p *parser) fixArrayVariables(nodes *[]node) {
 		if pos >= len(*nodes) {
 			break
 		}
-		if pos+1 >= len(*nodes) || (*nodes)[pos+1].tok != token.LPAREN {
-			// Example:
-			//  ingeter c(10)
-			//  call func(c) ! in function no LPAREN
-			pos += 1
-			continue
-		}
 		pos += 1
-		args, end := separateArgsParen((*nodes)[pos:])
-
-		// inject nodes
-		var inject []node
-		for i, a := range args {
-			begin := p.getArrayBegin(v.name, i)
-			for j := range a {
-				if a[j].tok == token.COLON {
-					// from : S[ I:I ]
-					// to   : S[ I ]
-					if j == 1 && len(a) == 3 {
-						a[j].tok, a[j].b = token.STRING, []byte(" ")
-						a[j+1].tok, a[j+1].b = token.STRING, []byte(" ")
-						// a = append(a[:j], append([]node{
-						// 	{tok: token.SUB, b: []byte("-")},
-						// 	{tok: token.LPAREN, b: []byte("(")},
-						// 	{
-						// 		tok: token.INT,
-						// 		b:   []byte(strconv.Itoa(begin)),
-						// 	},
-						// 	{tok: token.RPAREN, b: []byte(")")},
-						// }, a[j:]...)...)
-					}
-				}
+		next := pos
+		if p.getArrayLen(v.name) > 0 {
+			if pos >= len(*nodes) || (*nodes)[pos].tok != token.LPAREN {
+				// Example:
+				//  ingeter c(10)
+				//  call func(c) ! in function no LPAREN
+				continue
+			}
+			args, end := separateArgsParen((*nodes)[pos:])
+
+			// inject nodes
+			var inject []node
+			for i, a := range args {
+				inject = append(inject, arraySubscript(a, p.getArrayBegin(v.name, i))...)
 			}
-			inject = append(inject, node{tok: token.LBRACK, b: []byte("[")})
-			inject = append(inject, a...)
+
+			(*nodes) = append((*nodes)[:pos], append(inject, (*nodes)[pos+end:]...)...)
+			next = pos + len(inject)
+		}
+		if !v.typ.isCharacter() || next >= len(*nodes) || (*nodes)[next].tok != token.LPAREN {
+			continue
+		}
+		args, end := separateArgsParen((*nodes)[next:])
+		if len(args) != 1 {
+			continue
+		}
+		colon := findColon(args[0])
+		if colon < 0 {
+			continue
+		}
+		inject := []node{{tok: token.LBRACK, b: []byte("[")}}
+		if colon > 0 {
+			inject = append(inject, node{tok: token.LPAREN, b: []byte("(")})
+			inject = append(inject, args[0][:colon]...)
 			inject = append(inject, []node{
-				{tok: token.SUB, b: []byte("-")},
-				{tok: token.LPAREN, b: []byte("(")},
-				{
-					tok: token.INT,
-					b:   []byte(strconv.Itoa(begin)),
-				},
 				{tok: token.RPAREN, b: []byte(")")},
+				{tok: token.SUB, b: []byte("-")},
+				{tok: token.INT, b: []byte("1")},
 			}...)
-			inject = append(inject, node{tok: token.RBRACK, b: []byte("]")})
 		}
+		inject = append(inject, args[0][colon:]...)
+		inject = append(inject, node{tok: token.RBRACK, b: []byte("]")})
+		(*nodes) = append((*nodes)[:next], append(inject, (*nodes)[next+end:]...)...)
+	}
+}
 
-		(*nodes) = append((*nodes)[:pos], append(inject, (*nodes)[pos+end:]...)...)
-		pos += end
+// arraySubscript return index of Go slice for subscript of array with
+// lower bound begin. Subscript triplet without stride is slice.
+//
+// Example:
+//  I        - [ I - ( 1 ) ]
+//  2 : N    - [ ( 2 ) - ( 1 ) : N ]
+//  : N      - [ : ( N ) - ( -1 ) ]   for lower bound 0
+func arraySubscript(a []node, begin int) []node {
+	// minus return nodes: - ( begin )
+	minus := func(begin int) []node {
+		return []node{
+			{tok: token.SUB, b: []byte("-")},
+			{tok: token.LPAREN, b: []byte("(")},
+			{tok: token.INT, b: []byte(strconv.Itoa(begin))},
+			{tok: token.RPAREN, b: []byte(")")},
+		}
+	}
+	// paren return nodes: ( nodes )
+	paren := func(nodes []node) []node {
+		return append(append([]node{{tok: token.LPAREN, b: []byte("(")}}, nodes...),
+			node{tok: token.RPAREN, b: []byte(")")})
+	}
+	inject := []node{{tok: token.LBRACK, b: []byte("[")}}
+	colon := findColon(a)
+	if colon < 0 || findColon(a[colon+1:]) >= 0 {
+		// subscript or triplet with stride
+		inject = append(inject, a...)
+		inject = append(inject, minus(begin)...)
+		return append(inject, node{tok: token.RBRACK, b: []byte("]")})
+	}
+	if lo := a[:colon]; len(lo) > 0 {
+		inject = append(inject, paren(lo)...)
+		inject = append(inject, minus(begin)...)
+	}
+	inject = append(inject, node{tok: token.COLON, b: []byte(":")})
+	if hi := a[colon+1:]; len(hi) > 0 && begin == 1 {
+		inject = append(inject, hi...)
+	} else if len(hi) > 0 {
+		inject = append(inject, paren(hi)...)
+		inject = append(inject, minus(begin-1)...)
 	}
+	return append(inject, node{tok: token.RBRACK, b: []byte("]")})
+}
+
+// findColon return position of colon outside of parens or -1
+func findColon(nodes []node) int {
+	counter := 0
+	for i, n := range nodes {
+		switch n.tok {
+		case token.LPAREN:
+			counter++
+		case token.RPAREN:
+			counter--
+		case token.COLON:
+			if counter == 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 // Example:
@@ -388,6 +440,10 @@ func (p *parser) fixComplexValue(nodes *[]node) {
 	p.fixComplexValue(nodes)
 }
 
+// Example:
+//  S // 'ABC' // T
+// To :
+//  intrinsic.CONCAT ( S , intrinsic.CONCAT ( "ABC" , T ) )
 func (p *parser) fixConcatString(nodes *[]node) {
 	for {
 		var pos int
@@ -403,27 +459,19 @@ func (p *parser) fixConcatString(nodes *[]node) {
 			return
 		}
 
+		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+
 		leftOther, leftVariable, rightVariable, rightOther := p.split(nodes, pos)
 
 		// combine expression by next formula:
-		// leftOther append(append([]byte,leftVariable),rightVariable) rightOther
-		//           ---------------------            --             -
+		// leftOther intrinsic.CONCAT(leftVariable,rightVariable) rightOther
 		var comb []node
 		comb = append(comb, leftOther...)
 		comb = append(comb, []node{
-			{tok: token.IDENT, b: []byte("append")},
+			{tok: token.IDENT, b: []byte("intrinsic.CONCAT")},
 			{tok: token.LPAREN, b: []byte("(")},
-			{tok: token.IDENT, b: []byte("append")},
-			{tok: token.LPAREN, b: []byte("(")},
-			{tok: token.LBRACK, b: []byte("[")},
-			{tok: token.RBRACK, b: []byte("]")},
-			{tok: token.IDENT, b: []byte("byte")},
-			{tok: token.LBRACE, b: []byte("{")},
-			{tok: token.RBRACE, b: []byte("}")},
-			{tok: token.COMMA, b: []byte(",")},
 		}...)
 		comb = append(comb, leftVariable...)
-		comb = append(comb, node{tok: token.RPAREN, b: []byte(")")})
 		comb = append(comb, node{tok: token.COMMA, b: []byte(",")})
 		comb = append(comb, rightVariable...)
 		comb = append(comb, node{tok: token.RPAREN, b: []byte(")")})
@@ -442,7 +490,7 @@ func (p *parser) fixIdent(nodes *[]node) {
 			// for function
 			continue
 		}
-		if s := string((*nodes)[i].b); s == "false" || s == "true" {
+		if s := string((*nodes)[i].b); s == "false" || s == "true" || s == "nil" {
 			continue
 		}
 
@@ -28,17 +28,6 @@ func (c callArgumentSimplification) Visit(node goast.Node) (w goast.Visitor) {
 		}
 	}
 
-	// intrinsic return not pointer
-	if par, ok := node.(*goast.ParenExpr); ok {
-		if st, ok := par.X.(*goast.StarExpr); ok {
-			if call, ok := st.X.(*goast.CallExpr); ok {
-				if id, ok := call.Fun.(*goast.Ident); ok && strings.Contains(id.Name, "intrinsic") {
-					par.X = call
-				}
-			}
-		}
-	}
-
 	if call, ok := node.(*goast.CallExpr); ok {
 		for i := range call.Args {
 			switch a := call.Args[i].(type) {
@@ -70,18 +59,56 @@ type intrinsic struct {
 
 func (in intrinsic) Visit(node goast.Node) (w goast.Visitor) {
 
+	if a, ok := node.(*goast.AssignStmt); ok && a.Tok == token.ASSIGN &&
+		len(a.Lhs) == 1 && len(a.Rhs) == 1 {
+		goast.Walk(in, a.Lhs[0])
+		goast.Walk(in, a.Rhs[0])
+		in.p.assignConversion(a)
+		return nil
+	}
+
+	if be, ok := node.(*goast.BinaryExpr); ok {
+		goast.Walk(in, be.X)
+		goast.Walk(in, be.Y)
+		in.p.characterComparison(be)
+		in.p.binaryConversion(be)
+		return nil
+	}
+
+	if call, ok := node.(*goast.CallExpr); ok && isCharacterCall(call) {
+		goast.Walk(in, call.Fun)
+		for i := range call.Args {
+			goast.Walk(in, call.Args[i])
+			call.Args[i] = characterValue(call.Args[i], in.p.typeOf(call.Args[i]))
+		}
+		return nil
+	}
+
 	if call, ok := node.(*goast.CallExpr); ok {
 		if n, ok := call.Fun.(*goast.Ident); ok {
 			if f, ok := intrinsicFunction[strings.ToUpper(n.Name)]; ok {
+				// intrinsic functions in arguments are changed before
+				// and only once
+				for i := range call.Args {
+					goast.Walk(in, call.Args[i])
+				}
 				f(in.p, call)
-			} else {
-				switch n.Name {
-				case "make",
-					"append",
-					"panic",
-					"new",
-					"real":
-				default:
+				return nil
+			}
+			switch n.Name {
+			case "make",
+				"append",
+				"panic",
+				"new",
+				"real",
+				"imag",
+				"complex",
+				"len",
+				"float32",
+				"float64":
+			default:
+				if !strings.Contains(n.Name, ".") {
+					// name is not qualified by package
 					n.Name = strings.ToUpper(n.Name)
 				}
 			}
@@ -92,7 +119,7 @@ func (in intrinsic) Visit(node goast.Node) (w goast.Visitor) {
 		if sel, ok := call.Fun.(*goast.SelectorExpr); ok {
 			if x, ok := sel.X.(*goast.Ident); ok && x.Name == "intrinsic" {
 
-				var isRead bool = (sel.Sel.Name == "READ")
+				var isRead bool = (sel.Sel.Name == "READ" || sel.Sel.Name == "READU")
 
 				for i := range call.Args {
 					if isRead && i > 1 {
@@ -140,88 +167,10 @@ var intrinsicFunction = map[string]func(*parser, *goast.CallExpr){
 		typeNames := []string{"float64", "float64"}
 		intrinsicArgumentCorrection(p, f, "complex", typeNames)
 	},
-	"REAL": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"complex128"}
-		intrinsicArgumentCorrection(p, f, "real", typeNames)
-	},
-	"AIMAG": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"complex128"}
-		intrinsicArgumentCorrection(p, f, "imag", typeNames)
-	},
 	"LEN": func(p *parser, f *goast.CallExpr) {
 		typeNames := []string{"[]byte"}
 		intrinsicArgumentCorrection(p, f, "len", typeNames)
 	},
-	"MIN": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"int", "int"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.MIN", typeNames)
-	},
-	"MAX": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{any, any}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.MAX", typeNames)
-	},
-	"CONJG": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"complex128"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.CONJG", typeNames)
-	},
-	"DCONJG": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"complex128"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.DCONJG", typeNames)
-	},
-	"DBLE": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"int"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.DBLE", typeNames)
-	},
-	"ABS": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"float64"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.ABS", typeNames)
-	},
-	"DABS": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"float64"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.ABS", typeNames)
-	},
-	"CABS": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"complex128"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.CABS", typeNames)
-	},
-	"SIGN": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"float64"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.SIGN", typeNames)
-	},
-	"DSIGN": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"float64"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.DSIGN", typeNames)
-	},
-	"MOD": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"int", "int"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.MOD", typeNames)
-	},
-	"EPSILON": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"float64"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.EPSILON", typeNames)
-	},
-	"SQRT": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"any"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.SQRT", typeNames)
-	},
-	"CMPLX": func(p *parser, f *goast.CallExpr) {
-		typeNames := []string{"any"}
-		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-		intrinsicArgumentCorrection(p, f, "intrinsic.CMPLX", typeNames)
-	},
 }
 
 func intrinsicArgumentCorrection(p *parser, f *goast.CallExpr, name string, typeNames []string) {
@@ -261,3 +210,56 @@ func intrinsicArgumentCorrection(p *parser, f *goast.CallExpr, name string, type
 		}
 	}
 }
+
+// go/ast Visitor for add value of FUNCTION in return statements
+type returnValue struct {
+	name string
+}
+
+func (r returnValue) Visit(node goast.Node) (w goast.Visitor) {
+	switch n := node.(type) {
+	case *goast.FuncLit:
+		// return statements of function literals are not changed
+		return nil
+	case *goast.ReturnStmt:
+		n.Results = []goast.Expr{&goast.ParenExpr{
+			X: &goast.StarExpr{X: goast.NewIdent(r.name)},
+		}}
+	}
+	return r
+}
+
+// addReturnValue add value of FUNCTION in all return statements
+// Example:
+//  From :
+// return
+//  To:
+// return (*FUNC_RETURN)
+func addReturnValue(body *goast.BlockStmt, name string) {
+	r := returnValue{name: name}
+	goast.Walk(r, body)
+
+	// FUNCTION without RETURN at the end
+	for i := len(body.List) - 1; i >= 0; i-- {
+		if isComment(body.List[i]) {
+			continue
+		}
+		if _, ok := body.List[i].(*goast.ReturnStmt); ok {
+			return
+		}
+		break
+	}
+	ret := &goast.ReturnStmt{}
+	r.Visit(ret)
+	body.List = append(body.List, ret)
+}
+
+// isComment return true for statements with comment only
+func isComment(stmt goast.Stmt) bool {
+	if e, ok := stmt.(*goast.ExprStmt); ok {
+		if id, ok := e.X.(*goast.Ident); ok {
+			return strings.HasPrefix(id.Name, "//")
+		}
+	}
+	return false
+}
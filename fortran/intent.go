@@ -0,0 +1,550 @@
+package fortran
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// intent of argument
+type intent int
+
+const (
+	intentIn intent = iota
+	intentOut
+	intentInOut
+)
+
+const intentPrefix = "// INTENT("
+
+// intentComment return comment with INTENT of argument
+// Example:
+//
+//	// INTENT(IN) :: A
+func intentComment(in, name string) string {
+	return fmt.Sprintf("%s%s) :: %s", intentPrefix, in, name)
+}
+
+// parseIntentComment return INTENT and name of argument from comment
+func parseIntentComment(comment string) (in intent, name string, ok bool) {
+	if !strings.HasPrefix(comment, intentPrefix) {
+		return
+	}
+	comment = comment[len(intentPrefix):]
+	index := strings.Index(comment, ") :: ")
+	if index < 0 {
+		return
+	}
+	name = comment[index+len(") :: "):]
+	switch comment[:index] {
+	case "IN":
+		in = intentIn
+	case "OUT":
+		in = intentOut
+	case "INOUT":
+		in = intentInOut
+	default:
+		return
+	}
+	return in, name, true
+}
+
+// parameter of function
+type parameter struct {
+	name     string
+	typ      goast.Expr // type of parameter without pointer
+	pointer  bool
+	intent   intent
+	explicit bool // INTENT is defined in fortran source
+}
+
+type wrapper struct {
+	funcs  map[string]*goast.FuncDecl
+	params map[string][]*parameter
+}
+
+// Wrappers add for all functions with pointer arguments a wrapper
+// function with inputs by value and outputs as results. Arguments are
+// classified as IN, OUT and INOUT by INTENT from fortran source or
+// by usage of argument inside function:
+//   - IN    - argument is never assigned;
+//   - OUT   - argument is assigned before any usage;
+//   - INOUT - all other arguments.
+//
+// Name of wrapper is name of function in Go style.
+// Example, from:
+//
+//	func SROTG(SA *float32, SB *float32, C *float32, S *float32)
+//
+// to:
+//
+//	func SROTG(SA *float32, SB *float32, C *float32, S *float32)
+//
+//	// Srotg calls SROTG with arguments by value.
+//	// Results: sa, sb, c, s.
+//	func Srotg(sa float32, sb float32) (float32, float32, float32, float32) {
+//		var c float32
+//		var s float32
+//		SROTG(&sa, &sb, &c, &s)
+//		return sa, sb, c, s
+//	}
+func Wrappers(files []*goast.File) {
+	w := wrapper{
+		funcs:  map[string]*goast.FuncDecl{},
+		params: map[string][]*parameter{},
+	}
+	names := map[string]bool{}
+	for _, f := range files {
+		expandCode(f)
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *goast.FuncDecl:
+				names[decl.Name.Name] = true
+				if decl.Recv != nil || decl.Body == nil || decl.Name.Name == "main" {
+					continue
+				}
+				w.funcs[decl.Name.Name] = decl
+			case *goast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *goast.TypeSpec:
+						names[spec.Name.Name] = true
+					case *goast.ValueSpec:
+						for _, n := range spec.Names {
+							names[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	defer func() {
+		for _, f := range files {
+			collapseCode(f)
+		}
+	}()
+
+	w.classify()
+
+	for _, f := range files {
+		var decls []goast.Decl
+		for _, decl := range f.Decls {
+			decls = append(decls, decl)
+			fd, ok := decl.(*goast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if _, ok := w.funcs[fd.Name.Name]; !ok {
+				continue
+			}
+			name := goName(fd.Name.Name)
+			if name == "" || names[name] {
+				continue
+			}
+			if wd := w.generate(fd, name); wd != nil {
+				names[name] = true
+				decls = append(decls, wd)
+			}
+		}
+		f.Decls = decls
+	}
+}
+
+// classify define INTENT of all pointer arguments
+func (w *wrapper) classify() {
+	for name, fd := range w.funcs {
+		explicit := map[string]intent{}
+		for _, stmt := range fd.Body.List {
+			e, ok := stmt.(*goast.ExprStmt)
+			if !ok {
+				continue
+			}
+			id, ok := e.X.(*goast.Ident)
+			if !ok {
+				continue
+			}
+			if in, n, ok := parseIntentComment(id.Name); ok {
+				explicit[n] = in
+			}
+		}
+
+		var params []*parameter
+		for _, field := range fd.Type.Params.List {
+			for _, n := range field.Names {
+				p := parameter{name: n.Name, typ: field.Type, intent: intentIn}
+				if st, ok := field.Type.(*goast.StarExpr); ok {
+					p.typ = st.X
+					p.pointer = true
+					p.intent = intentInOut
+				}
+				if in, ok := explicit[n.Name]; ok && p.pointer {
+					p.intent = in
+					p.explicit = true
+				}
+				params = append(params, &p)
+			}
+		}
+		w.params[name] = params
+	}
+
+	// all arguments are INOUT at the begin, so analyze is repeated
+	// until INTENT of arguments of called functions is not changed
+	for iter := 0; iter <= len(w.funcs); iter++ {
+		changed := false
+		for name, params := range w.params {
+			for _, p := range params {
+				if !p.pointer || p.explicit {
+					continue
+				}
+				in := w.analyze(w.funcs[name].Body, p.name)
+				if in != p.intent {
+					p.intent = in
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+}
+
+// analyze return INTENT of argument by usage inside body of function
+func (w *wrapper) analyze(body *goast.BlockStmt, name string) intent {
+	f := flow{w: w, name: name}
+	f.stmt(body)
+	f.exit()
+	switch {
+	case !f.written:
+		return intentIn
+	case f.readFirst:
+		return intentInOut
+	}
+	return intentOut
+}
+
+// argIntent return INTENT of argument of called function
+func (w *wrapper) argIntent(fun goast.Expr, pos int) intent {
+	id, ok := fun.(*goast.Ident)
+	if !ok {
+		return intentInOut
+	}
+	params, ok := w.params[id.Name]
+	if !ok || pos >= len(params) || !params[pos].pointer {
+		return intentInOut
+	}
+	return params[pos].intent
+}
+
+// flow is analyzer of argument usage in order of execution
+type flow struct {
+	w    *wrapper
+	name string
+
+	assigned  bool // argument is assigned on all paths
+	written   bool // argument is assigned somewhere
+	readFirst bool // value of argument is used before assignment
+
+	literals int // level of function literals
+}
+
+func (f *flow) read() {
+	if !f.assigned {
+		f.readFirst = true
+	}
+}
+
+func (f *flow) write() {
+	f.assigned = true
+	f.written = true
+}
+
+// exit is check of argument at the return from function, because
+// value of argument is returned to caller without assignment
+func (f *flow) exit() {
+	if f.written && !f.assigned {
+		f.readFirst = true
+	}
+}
+
+// isArg return true for expression like: A, (A)
+func (f *flow) isArg(e goast.Expr) bool {
+	id, ok := removeParen(e).(*goast.Ident)
+	return ok && id.Name == f.name
+}
+
+// isValue return true for expression like: *A, (*(A))
+func (f *flow) isValue(e goast.Expr) bool {
+	st, ok := removeParen(e).(*goast.StarExpr)
+	return ok && f.isArg(st.X)
+}
+
+func (f *flow) stmt(stmt goast.Stmt) {
+	switch s := stmt.(type) {
+	case nil:
+	case *goast.BlockStmt:
+		for _, st := range s.List {
+			f.stmt(st)
+		}
+	case *goast.LabeledStmt:
+		// any goto may be used for jump to label
+		f.assigned = false
+		f.stmt(s.Stmt)
+	case *goast.AssignStmt:
+		for _, e := range s.Rhs {
+			f.expr(e)
+		}
+		for _, e := range s.Lhs {
+			if !f.isValue(e) {
+				f.expr(e)
+				continue
+			}
+			if s.Tok != token.ASSIGN && s.Tok != token.DEFINE {
+				f.read()
+			}
+			f.write()
+		}
+	case *goast.IncDecStmt:
+		if f.isValue(s.X) {
+			f.read()
+			f.write()
+		} else {
+			f.expr(s.X)
+		}
+	case *goast.IfStmt:
+		f.stmt(s.Init)
+		f.expr(s.Cond)
+		before := f.assigned
+		f.stmt(s.Body)
+		body := f.assigned
+		f.assigned = before
+		f.stmt(s.Else)
+		f.assigned = body && f.assigned
+	case *goast.ForStmt:
+		f.stmt(s.Init)
+		f.expr(s.Cond)
+		before := f.assigned
+		f.stmt(s.Body)
+		f.stmt(s.Post)
+		f.assigned = before
+	case *goast.ReturnStmt:
+		for _, e := range s.Results {
+			f.expr(e)
+		}
+		if f.literals == 0 {
+			f.exit()
+		}
+	case *goast.SwitchStmt:
+		f.stmt(s.Init)
+		f.expr(s.Tag)
+		before := f.assigned
+		for _, c := range s.Body.List {
+			f.assigned = before
+			f.expr(c)
+		}
+		f.assigned = before
+	default:
+		f.expr(stmt)
+	}
+}
+
+func (f *flow) expr(node goast.Node) {
+	if node == nil {
+		return
+	}
+	goast.Inspect(node, func(n goast.Node) bool {
+		switch n := n.(type) {
+		case *goast.FuncLit:
+			// function literals are called in place
+			f.literals++
+			f.stmt(n.Body)
+			f.literals--
+			return false
+		case goast.Stmt:
+			if n != node {
+				f.stmt(n)
+				return false
+			}
+		case *goast.StarExpr:
+			if f.isArg(n.X) {
+				f.read()
+				return false
+			}
+		case *goast.UnaryExpr:
+			if n.Op == token.AND && f.isValue(n.X) {
+				f.read()
+				f.write()
+				return false
+			}
+		case *goast.CallExpr:
+			f.expr(n.Fun)
+			for i, a := range n.Args {
+				if !f.isArg(a) {
+					f.expr(a)
+					continue
+				}
+				switch f.w.argIntent(n.Fun, i) {
+				case intentIn:
+					f.read()
+				case intentOut:
+					f.write()
+				default:
+					f.read()
+					f.write()
+				}
+			}
+			return false
+		case *goast.SelectorExpr:
+			// COMMON.A is not argument
+			f.expr(n.X)
+			return false
+		case *goast.Ident:
+			if n.Name == f.name {
+				// unknown usage of pointer
+				f.read()
+				f.write()
+				return true
+			}
+			for _, name := range codeIdents(n.Name) {
+				if name == f.name {
+					f.read()
+					f.write()
+				}
+			}
+		case *goast.BasicLit:
+			for _, name := range codeIdents(n.Value) {
+				if name == f.name {
+					f.read()
+					f.write()
+				}
+			}
+		}
+		return true
+	})
+}
+
+// generate wrapper of function
+func (w *wrapper) generate(fd *goast.FuncDecl, name string) *goast.FuncDecl {
+	params := w.params[fd.Name.Name]
+	pointers := false
+	for _, p := range params {
+		pointers = pointers || p.pointer
+	}
+	if !pointers {
+		return nil
+	}
+
+	used := map[string]bool{}
+	for _, p := range params {
+		used[argName(p.name)] = true
+	}
+
+	var (
+		wd = goast.FuncDecl{
+			Name: goast.NewIdent(name),
+			Type: &goast.FuncType{
+				Params:  &goast.FieldList{},
+				Results: &goast.FieldList{},
+			},
+			Body: &goast.BlockStmt{},
+		}
+		args    []goast.Expr
+		results []goast.Expr
+		outputs []string
+	)
+	for _, p := range params {
+		n := argName(p.name)
+		if p.intent != intentOut || !p.pointer {
+			wd.Type.Params.List = append(wd.Type.Params.List, &goast.Field{
+				Names: []*goast.Ident{goast.NewIdent(n)},
+				Type:  p.typ,
+			})
+		} else {
+			// var C float32
+			wd.Body.List = append(wd.Body.List, &goast.DeclStmt{
+				Decl: &goast.GenDecl{
+					Tok: token.VAR,
+					Specs: []goast.Spec{&goast.ValueSpec{
+						Names: []*goast.Ident{goast.NewIdent(n)},
+						Type:  p.typ,
+					}},
+				},
+			})
+		}
+		if !p.pointer {
+			args = append(args, goast.NewIdent(n))
+			continue
+		}
+		args = append(args, &goast.UnaryExpr{Op: token.AND, X: goast.NewIdent(n)})
+		if p.intent == intentIn {
+			continue
+		}
+		wd.Type.Results.List = append(wd.Type.Results.List, &goast.Field{Type: p.typ})
+		results = append(results, goast.NewIdent(n))
+		outputs = append(outputs, n)
+	}
+
+	call := &goast.CallExpr{Fun: goast.NewIdent(fd.Name.Name), Args: args}
+	if fd.Type.Results == nil || len(fd.Type.Results.List) == 0 {
+		wd.Body.List = append(wd.Body.List, &goast.ExprStmt{X: call})
+	} else {
+		// result of function
+		r := "result"
+		for used[r] {
+			r += "_"
+		}
+		wd.Body.List = append(wd.Body.List, &goast.AssignStmt{
+			Lhs: []goast.Expr{goast.NewIdent(r)},
+			Tok: token.DEFINE,
+			Rhs: []goast.Expr{call},
+		})
+		typ := fd.Type.Results.List[0].Type
+		field := &goast.Field{Type: typ}
+		if id, ok := typ.(*goast.Ident); ok && id.Name == "error" {
+			wd.Type.Results.List = append(wd.Type.Results.List, field)
+			results = append(results, goast.NewIdent(r))
+			outputs = append(outputs, "error")
+		} else {
+			wd.Type.Results.List = append([]*goast.Field{field}, wd.Type.Results.List...)
+			results = append([]goast.Expr{goast.NewIdent(r)}, results...)
+			outputs = append([]string{r}, outputs...)
+		}
+	}
+	wd.Body.List = append(wd.Body.List, &goast.ReturnStmt{Results: results})
+
+	doc := fmt.Sprintf("// %s calls %s with arguments by value.", name, fd.Name.Name)
+	if len(outputs) > 0 {
+		doc += "\n// Results: " + strings.Join(outputs, ", ") + "."
+	}
+	wd.Doc = &goast.CommentGroup{}
+	for _, line := range strings.Split(doc, "\n") {
+		wd.Doc.List = append(wd.Doc.List, &goast.Comment{Text: line})
+	}
+	return &wd
+}
+
+// goName return name of function in Go style
+// Example:
+//
+//	DGEMM -> Dgemm
+func goName(name string) string {
+	if name == "" || strings.ToUpper(name) != name {
+		return ""
+	}
+	return name[:1] + strings.ToLower(name[1:])
+}
+
+// argName return name of argument in Go style
+// Example:
+//
+//	LDA   -> lda
+//	RANGE -> range_
+func argName(name string) string {
+	name = strings.ToLower(name)
+	if token.Lookup(name).IsKeyword() || types.Universe.Lookup(name) != nil {
+		name += "_"
+	}
+	return name
+}
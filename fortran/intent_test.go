@@ -0,0 +1,61 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrappers(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE ROTG(SA, SB, C, S)
+      REAL SA, SB, C, S
+      C = SA
+      IF (SB .GT. 0) THEN
+         S = SB
+      ELSE
+         S = 0
+      END IF
+      SA = SA + SB
+      END
+
+      SUBROUTINE CONDW(N, K)
+      INTEGER N, K
+      IF (N .GT. 0) THEN
+         K = 1
+      END IF
+      END
+
+      SUBROUTINE CALLR(A, B, X)
+      REAL A, B, X, Y
+      CALL ROTG(A, B, X, Y)
+      END
+
+      SUBROUTINE EXPL(A, B, C)
+      INTEGER, INTENT(IN) :: A
+      INTEGER, INTENT(OUT) :: B
+      INTEGER, INTENT(INOUT) :: C
+      B = A
+      END
+
+      INTEGER FUNCTION INCR(RANGE)
+      INTEGER RANGE
+      INCR = RANGE + 1
+      END
+`, Wrappers)
+
+	for _, s := range []string{
+		"func Rotg(sa float64, sb float64) (float64, float64, float64)",
+		"return sa, c, s",
+		"func Condw(n int, k int) int",
+		"func Callr(a float64, b float64) (float64, float64)",
+		"// INTENT(OUT) :: B",
+		"func Expl(a int, c int) (int, int)",
+		"// Incr calls INCR with arguments by value.",
+		"func Incr(range_ int) int",
+		"result := INCR(&range_)",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
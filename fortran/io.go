@@ -4,11 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	goast "go/ast"
-	goparser "go/parser"
 	"go/token"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 func (p *parser) convertLineToComment() (stmts []goast.Stmt){
@@ -21,31 +19,37 @@ func (p *parser) convertLineToComment() (stmts []goast.Stmt){
 	return
 }
 
+// parsePosition return statement of file positioning: REWIND,
+// BACKSPACE, ENDFILE.
+//
 // Example:
 //  REWIND NTRA
 //  REWIND MSTP(161)
-func (p *parser) parseRewind() (stmts []goast.Stmt) {
-	p.expect(ftRewind)
+//  BACKSPACE ( UNIT = LFILE , IOSTAT = IOS )
+//  END FILE 10
+func (p *parser) parsePosition() (stmts []goast.Stmt) {
+	name := view(p.ns[p.ident].tok)
 	p.ident++
 
-	var name string
-	for ; p.ident < len(p.ns) && p.ns[p.ident].tok != ftNewLine; p.ident++ {
-		name += string(p.ns[p.ident].b)
+	var specs map[string][]node
+	if p.ns[p.ident].tok == token.LPAREN {
+		args, end := separateArgsParen(p.ns[p.ident:])
+		p.ident += end
+		specs = controlList(args)
+	} else {
+		var unit []node
+		for ; p.ns[p.ident].tok != ftNewLine; p.ident++ {
+			unit = append(unit, p.ns[p.ident])
+		}
+		specs = map[string][]node{"UNIT": unit}
 	}
 	p.expect(ftNewLine)
 
-	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-
-	s := fmt.Sprintf("intrinsic.REWIND(%s)", name)
-
-	ast, err := goparser.ParseExpr(s)
-	if err != nil {
-		panic(fmt.Errorf("pos:%v\nSource : %v\n Error: %v", p.ns[p.ident].pos, s, err))
+	unit, ok := specs["UNIT"]
+	if !ok {
+		panic(fmt.Errorf("%s without UNIT: %s", name, p.getLine()))
 	}
-
-	return append(stmts, &goast.ExprStmt{
-		X: ast,
-	})
+	return p.ioCall(name, specs, p.parseUnit(unit))
 }
 
 // Example:
@@ -53,247 +57,591 @@ func (p *parser) parseRewind() (stmts []goast.Stmt) {
 //  9999 FORMAT ( ' ** On entry to ' , A , ' parameter number ' , I2 , ' had ' , 'an illegal value' )
 //
 // write (*, '(I1,A2,I1)') i,'YY',i
+//  WRITE ( NOUT , FMT = 9999 ) ( IDIM ( I ) , I = 1 , NIDIM )
 func (p *parser) parseWrite() (stmts []goast.Stmt) {
+	p.expect(ftWrite)
+	p.ident++
+	p.expect(token.LPAREN)
 
-	return p.convertLineToComment()
-
-	{
-		start := p.ident
-		p.expect(ftWrite)
-		p.ident++
-		p.expect(token.LPAREN)
-
-		// WRITE ( 1, *) R
-		//       ========= this out
-		args, end := separateArgsParen(p.ns[p.ident:])
-		p.ident += end
-
-		// Pattern:
-		//  WRITE( UNIT = ..., FMT = ...)
-		// Other parameters are ignored
-
-		// Part : UNIT
-		unit := string(args[0][0].b)
-		if len(args[0]) == 3 {
-			unit = string(args[0][2].b)
-		}
-		if unit == "*" {
-			unit = "6"
-		}
+	// WRITE ( 1, *) R
+	//       ========= this out
+	args, end := separateArgsParen(p.ns[p.ident:])
+	p.ident += end
+	specs := controlList(args)
 
-		// Part: FMT
-		fmts := args[1][0]
-		if len(args[1]) == 3 {
-			fmts = args[1][2]
-		}
+	// Part : UNIT
+	unit, ok := specs["UNIT"]
+	if !ok {
+		panic(fmt.Errorf("WRITE without UNIT: %s", nodesToString(p.ns[p.ident-end:p.ident])))
+	}
 
-		var fs string
-		if fmts.tok == token.INT {
-			line := p.getLineByLabel(fmts.b)
-			fs = p.parseFormat(line[2:])
-		} else if fmts.tok == token.MUL {
-			// Example: *
-			fs = "\" %v\\n\""
-		} else {
-			// Example :
-			// '(A80)'
-			ns := scan(fmts.b[2 : len(fmts.b)-2])
-			fs = p.parseFormat(ns)
-		}
+	// Part: NML
+	// Example:
+	//  WRITE ( 6 , NML = PARAMS )
+	if nml, ok := p.namelist(specs); ok {
+		p.gotoEndLine()
+		return p.ioCall("WRITENML", specs, p.parseRecord(unit, specs), nml)
+	}
 
-		p.ns = append(p.ns[:start+2], append(scan([]byte(fmt.Sprintf("%v , %v )", unit, fs))), p.ns[p.ident:]...)...)
+	// Part: FMT
+	// Example of unformatted output:
+	//  WRITE ( IOS ) NSTEP , TTIM , ( U ( I ) , I = 1 , NNEQ )
+	var format goast.Expr
+	if f, ok := specs["FMT"]; ok {
+		format = p.parseFormat(f)
+	}
 
-		p.ident = start
+	// output list
+	// Example:
+	//  WRITE ( 6, 100 ) , A
+	if p.ns[p.ident].tok == token.COMMA {
+		p.ident++
+	}
+	var list []node
+	for ; p.ns[p.ident].tok != ftNewLine; p.ident++ {
+		list = append(list, p.ns[p.ident])
 	}
-	start := p.ident
-	p.ns = append(p.ns[:p.ident], append([]node{{tok: ftCall, b: []byte("call")}}, p.ns[p.ident:]...)...)
 
+	return p.writeCall(specs, p.parseRecord(unit, specs), format, list)
+}
+
+// Example:
+//  PRINT *, 'Result = ', R
+//  PRINT *
+//  PRINT 100, N
+//  PRINT '(A,I3)', 'N = ', N
+func (p *parser) parsePrint() (stmts []goast.Stmt) {
+	p.expect(ftPrint)
 	p.ident++
-	p.expect(ftWrite)
-	p.ns[p.ident].tok = token.IDENT
-	p.ns[p.ident].b = []byte("intrinsic.WRITE")
-	p.ident++
-	p.expect(token.LPAREN)
 
-	counter := 0
-	for ; ; p.ident++ {
-		if p.ns[p.ident].tok == token.LPAREN {
+	// format is finished by first comma outside of parenthesis
+	var format []node
+	for counter := 0; p.ns[p.ident].tok != ftNewLine; p.ident++ {
+		switch p.ns[p.ident].tok {
+		case token.LPAREN:
 			counter++
-		}
-		if p.ns[p.ident].tok == token.RPAREN {
+		case token.RPAREN:
 			counter--
 		}
-		if counter == 0 {
+		if counter == 0 && p.ns[p.ident].tok == token.COMMA {
+			p.ident++
 			break
 		}
+		format = append(format, p.ns[p.ident])
 	}
-	p.ns[p.ident].tok = token.COMMA
-	p.ns[p.ident].b = []byte(",")
-	for ; ; p.ident++ {
-		if p.ns[p.ident].tok == ftNewLine {
-			break
-		}
+	if len(format) == 0 {
+		panic(fmt.Errorf("PRINT without format: %s", p.getLine()))
 	}
-	p.ns = append(p.ns[:p.ident], append([]node{
-		{tok: token.RPAREN, b: []byte(")")},
-	}, p.ns[p.ident:]...)...)
 
-	p.ident = start
+	var list []node
+	for ; p.ns[p.ident].tok != ftNewLine; p.ident++ {
+		list = append(list, p.ns[p.ident])
+	}
 
-	return p.parseStmt()
+	// output is in standard unit
+	return p.writeCall(nil, goast.NewIdent("6"), p.parseFormat(format), list)
 }
 
-func (p *parser) getLineByLabel(label []byte) (fs []node) {
+// writeCall return statements of output list in unit by format with
+// specifiers IOSTAT, ERR. Output is unformatted, if format is nil.
+//
+// Example:
+//  intrinsic.WRITE(6, nil, []byte("(I5)"), N)
+//  intrinsic.WRITEU(IOS, nil, N)
+func (p *parser) writeCall(specs map[string][]node, unit, format goast.Expr, list []node) []goast.Stmt {
+	if format == nil {
+		return p.ioCall("WRITEU", specs, append([]goast.Expr{unit},
+			p.parseIOList(list)...)...)
+	}
+	return p.ioCall("WRITE", specs, append([]goast.Expr{unit, format},
+		p.parseIOList(list)...)...)
+}
 
-	// memorization of FORMAT lines
-	if v, ok := p.formats[string(label)]; ok {
-		return v
+// controlList return specifiers of I/O statement by names.
+// First specifiers without names are UNIT and FMT.
+//
+// Example:
+//  ( NOUT , FMT = 9999 )
+//  ( UNIT = 2 , FILE = "./testdata/main.f" )
+//  ( * , * )
+func controlList(args [][]node) (specs map[string][]node) {
+	specs = map[string][]node{}
+	positional := []string{"UNIT", "FMT"}
+	for i, arg := range args {
+		if len(arg) > 1 && arg[1].tok == token.ASSIGN {
+			specs[strings.ToUpper(string(arg[0].b))] = arg[2:]
+			continue
+		}
+		if i < len(positional) {
+			specs[positional[i]] = arg
+		}
 	}
+	return
+}
 
-	var found bool
-	var st int
-	for st = p.ident; st < len(p.ns); st++ {
-		if p.ns[st-1].tok == ftNewLine && bytes.Equal(p.ns[st].b, label) {
-			found = true
-			break
+// parseUnit return expression of unit
+//
+// Example:
+//  *
+//  NOUT
+func (p *parser) parseUnit(unit []node) goast.Expr {
+	if len(unit) == 1 && unit[0].tok == token.MUL {
+		// standard output
+		return goast.NewIdent("6")
+	}
+	return p.parseExprNodes(unit)
+}
+
+// parseRecord return expression of unit for data transfer. Unit with
+// specifier REC is record of direct access file.
+//
+// Example:
+//  WRITE ( 10 , REC = K ) A
+//
+// result:
+//
+//	intrinsic.Record{Unit: 10, REC: (*K)}
+func (p *parser) parseRecord(unit []node, specs map[string][]node) goast.Expr {
+	rec, ok := specs["REC"]
+	if !ok {
+		return p.parseUnit(unit)
+	}
+	return &goast.CompositeLit{
+		Type: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent("Record"),
+		},
+		Elts: []goast.Expr{
+			&goast.KeyValueExpr{
+				Key:   goast.NewIdent("Unit"),
+				Value: p.parseUnit(unit),
+			},
+			&goast.KeyValueExpr{
+				Key:   goast.NewIdent("REC"),
+				Value: p.parseExprNodes(rec),
+			},
+		},
+	}
+}
+
+// parseNamelist store variables of NAMELIST groups
+//
+// Example:
+//  NAMELIST / PARAMS / A , B , N / OUT / X
+func (p *parser) parseNamelist() {
+	p.expect(ftNamelist)
+	p.ident++
+	var group string
+	for ; p.ns[p.ident].tok != ftNewLine; p.ident++ {
+		switch n := p.ns[p.ident]; n.tok {
+		case token.QUO: // /
+			p.ident++
+			group = strings.ToUpper(string(p.ns[p.ident].b))
+			p.ident++
+			p.expect(token.QUO)
+		case token.COMMA:
+		default:
+			if group == "" {
+				panic(fmt.Errorf("NAMELIST without group name: %s", p.getLine()))
+			}
+			p.namelists[group] = append(p.namelists[group], n)
 		}
 	}
-	if !found {
-		p.addError("Cannot found label :" + string(label))
-		return
+	p.expect(ftNewLine)
+}
+
+// namelist return expression of NAMELIST group of data transfer.
+// Group is specifier NML or format with name of group.
+//
+// Example:
+//  READ ( 5 , NML = PARAMS )
+//  WRITE ( 6 , PARAMS )
+//
+// result:
+//
+//	intrinsic.Namelist{Name: "PARAMS", Names: []string{"A", "N"},
+//		Items: []interface{}{&(*A), &(*N)}}
+func (p *parser) namelist(specs map[string][]node) (_ goast.Expr, ok bool) {
+	group, ok := specs["NML"]
+	if !ok {
+		group, ok = specs["FMT"]
+	}
+	if !ok || len(group) != 1 || group[0].tok != token.IDENT {
+		return nil, false
+	}
+	name := strings.ToUpper(string(group[0].b))
+	vars, ok := p.namelists[name]
+	if !ok {
+		return nil, false
+	}
+	names := &goast.CompositeLit{Type: goast.NewIdent("[]string")}
+	items := &goast.CompositeLit{Type: goast.NewIdent("[]interface{}")}
+	for _, v := range vars {
+		names.Elts = append(names.Elts, goast.NewIdent(fmt.Sprintf("%q", v.b)))
+		items.Elts = append(items.Elts, p.parseInputList([]node{v})...)
 	}
+	return &goast.CompositeLit{
+		Type: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent("Namelist"),
+		},
+		Elts: []goast.Expr{
+			&goast.KeyValueExpr{
+				Key:   goast.NewIdent("Name"),
+				Value: goast.NewIdent(fmt.Sprintf("%q", name)),
+			},
+			&goast.KeyValueExpr{Key: goast.NewIdent("Names"), Value: names},
+			&goast.KeyValueExpr{Key: goast.NewIdent("Items"), Value: items},
+		},
+	}, true
+}
 
-	for i := st; i < len(p.ns) && p.ns[i].tok != ftNewLine; i++ {
-		fs = append(fs, p.ns[i])
-		// remove line
-		p.ns[i].tok, p.ns[i].b = ftNewLine, []byte("\n")
+// parseFormat return expression of format specification.
+// List-directed formatting `*` is nil.
+//
+// Example:
+//  *
+//  9999
+//  '(A80)'
+//  FMTSTR
+func (p *parser) parseFormat(format []node) goast.Expr {
+	if len(format) == 1 {
+		switch format[0].tok {
+		case token.MUL:
+			return goast.NewIdent("nil")
+		case token.INT:
+			line := p.getLineByLabel(format[0].b)
+			if line == nil {
+				panic(fmt.Errorf("cannot find FORMAT with label %s", format[0].b))
+			}
+			var fs string
+			for _, n := range line {
+				if n.tok == token.STRING {
+					// from: " it's "
+					// to  : ' it''s '
+					fs += "'" + strings.Replace(string(n.b[1:len(n.b)-1]), "'", "''", -1) + "'"
+					continue
+				}
+				fs += string(n.b)
+			}
+			return goast.NewIdent(fmt.Sprintf("[]byte(%s)", strconv.Quote(fs)))
+		case token.STRING:
+			return goast.NewIdent(fmt.Sprintf("[]byte(%s)",
+				strconv.Quote(string(format[0].b[1:len(format[0].b)-1]))))
+		}
 	}
+	return p.parseExprNodes(format)
+}
 
-	p.formats[string(label)] = fs
+// parseIOList return expressions of input/output list.
+// Implied-DO lists are function literals with list of items.
+//
+// Example:
+//  SRNAME ( 1 : LEN_TRIM ( SRNAME ) ) , INFO
+//  ( IDIM ( I ) , I = 1 , NIDIM )
+//  ( ( A ( I , J ) , I = 1 , M ) , J = 1 , N )
+func (p *parser) parseIOList(list []node) (exprs []goast.Expr) {
+	return p.ioList(list, false)
+}
 
-	return
+// parseInputList return addresses of items of input list
+//
+// Example:
+//  N , A ( I ) , S ( 1 : 5 )
+//  ( IDIM ( I ) , I = 1 , NIDIM )
+func (p *parser) parseInputList(list []node) (exprs []goast.Expr) {
+	return p.ioList(list, true)
 }
 
-func (p *parser) parseFormat(in []node) (s string) {
-	var fs []node
-	fs = append(fs, in...)
-	if len(fs) == 0 {
-		s = "\"\\n\""
+func (p *parser) ioList(list []node, input bool) (exprs []goast.Expr) {
+	if len(list) == 0 {
 		return
 	}
-	// From:
-	//  ... / ...
-	// To:
-	//  ... , "\\n" , ...
-	for i := 0; i < len(fs); i++ {
-		if fs[i].tok != token.QUO { // not /
+	items, _ := separateArgsParen(append(append([]node{
+		{tok: token.LPAREN, b: []byte("(")}},
+		list...),
+		node{tok: token.RPAREN, b: []byte(")")}))
+	for _, item := range items {
+		if loop, ok := impliedDo(item); ok {
+			exprs = append(exprs, p.parseImpliedDo(loop, input))
 			continue
 		}
-		fs = append(fs[:i], append([]node{
-			{tok: token.COMMA, b: []byte(",")},
-			{tok: token.STRING, b: []byte("\\n")},
-			{tok: token.COMMA, b: []byte(",")},
-		}, fs[i+1:]...)...)
-	}
-
-	for i := 0; i < len(fs); i++ {
-		f := fs[i]
-		switch f.tok {
-		case token.IDENT, token.COMMENT:
-			switch byte(unicode.ToUpper(rune(f.b[0]))) {
-			case 'I':
-				s += "%" + string(f.b[1:]) + "d"
-			case 'F', 'G', 'P':
-				s += "%" + string(f.b[1:])
-				if i+1 < len(fs) && fs[i+1].tok == token.PERIOD {
-					i += 1
-					s += "."
-					if i+1 < len(fs) && fs[i+1].tok == token.INT {
-						s += string(fs[i+1].b)
-						i += 1
-					}
-				}
-				s += "f"
-			case 'E', 'D':
-				s += "%" + string(f.b[1:])
-				if i+1 < len(fs) && fs[i+1].tok == token.PERIOD {
-					i += 1
-					s += "."
-					if i+1 < len(fs) && fs[i+1].tok == token.INT {
-						s += string(fs[i+1].b)
-						i += 1
-					}
-				}
-				s += "E"
-			case 'A':
-				if string(f.b) == "A1" {
-					s += "%c"
-				} else {
-					if len(f.b) > 1 {
-						s += "%" + string(f.b[1:]) + "s"
-					} else {
-						s += "%s"
-					}
-				}
+		if len(item) == 1 && item[0].tok == token.STRING && !input {
+			exprs = append(exprs, goast.NewIdent(fmt.Sprintf("[]byte(%s)", item[0].b)))
+			continue
+		}
+		expr := p.parseExprNodes(item)
+		if input {
+			if _, ok := expr.(*goast.SliceExpr); !ok {
+				// substring is slice of variable
+				expr = &goast.UnaryExpr{Op: token.AND, X: expr}
+			}
+		}
+		exprs = append(exprs, expr)
+	}
+	return
+}
 
-			case 'L':
-				v, _ := strconv.Atoi(string(f.b[1:]))
-				for i := 0; i < v; i++ {
-					s += " "
-				}
-				s += "%t"
+// impliedDo return parts of implied-DO list: items and loop control
+//
+// Example:
+//  ( A ( I ) , B ( I ) , I = 1 , N , 2 )
+//    =========   =========   =====   =   =
+//    0           1           2       3   4
+func impliedDo(item []node) (parts [][]node, ok bool) {
+	if len(item) < 2 || item[0].tok != token.LPAREN ||
+		item[len(item)-1].tok != token.RPAREN {
+		return nil, false
+	}
+	parts, end := separateArgsParen(item)
+	if end != len(item) {
+		return nil, false
+	}
+	for i := range parts {
+		if len(parts[i]) > 1 && parts[i][1].tok == token.ASSIGN {
+			return parts, i > 0 && (len(parts)-i == 2 || len(parts)-i == 3)
+		}
+	}
+	return nil, false
+}
 
-			default:
-				p.addError(fmt.Sprintf(
-					"Not support format pos = %v: %s", in[0].pos, f))
-			}
-		case token.INT:
-			// 1X
-			// 5X
-			v, _ := strconv.Atoi(string(f.b))
-			if byte(unicode.ToUpper(rune(fs[i+1].b[0]))) == 'X' {
-				for i := 0; i < v; i++ {
-					s += " "
-				}
-				i++
-			}
+// parseImpliedDo return function literal with items of implied-DO list
+//
+//	func() (items []interface{}) {
+//		for (*I) = 1; (*I) <= (*N); (*I)++ {
+//			items = append(items, (*A)[(*I)-(1)])
+//		}
+//		return
+//	}()
+func (p *parser) parseImpliedDo(parts [][]node, input bool) goast.Expr {
+	var loop int
+	for loop = range parts {
+		if len(parts[loop]) > 1 && parts[loop][1].tok == token.ASSIGN {
+			break
+		}
+	}
+	var list []node
+	for i := 0; i < loop; i++ {
+		if i > 0 {
+			list = append(list, node{tok: token.COMMA, b: []byte(",")})
+		}
+		list = append(list, parts[i]...)
+	}
+	items := goast.NewIdent("items")
+	body := &goast.AssignStmt{
+		Lhs: []goast.Expr{items},
+		Tok: token.ASSIGN,
+		Rhs: []goast.Expr{&goast.CallExpr{
+			Fun:  goast.NewIdent("append"),
+			Args: append([]goast.Expr{items}, p.ioList(list, input)...),
+		}},
+	}
+	return &goast.CallExpr{
+		Fun: &goast.FuncLit{
+			Type: &goast.FuncType{
+				Params: &goast.FieldList{},
+				Results: &goast.FieldList{List: []*goast.Field{{
+					Names: []*goast.Ident{items},
+					Type:  goast.NewIdent("[]interface{}"),
+				}}},
+			},
+			Body: &goast.BlockStmt{List: []goast.Stmt{
+				p.createForArguments(parts[loop:], body),
+				&goast.ReturnStmt{},
+			}},
+		},
+	}
+}
 
-		case token.STRING:
-			str := string(f.b)
-			if str[0] == '"' || str[0] == '\'' {
-				s += str[1 : len(str)-1]
-			} else {
-				s += str
-			}
-		case token.COMMA, token.LPAREN, token.RPAREN:
-			// ignore
-		default:
-			s += "%v"
+// getLineByLabel return nodes of FORMAT with label after word FORMAT.
+// FORMAT can be located anywhere in program unit.
+func (p *parser) getLineByLabel(label []byte) (fs []node) {
+
+	// memorization of FORMAT lines
+	if v, ok := p.formats[string(label)]; ok {
+		return v
+	}
+
+	isHeader := func(n node) bool {
+		return n.tok == ftSubroutine || n.tok == ftProgram || n.tok == ftFunction
+	}
+
+	// begin of program unit
+	st := p.ident
+	for st > 0 && !isHeader(p.ns[st]) {
+		st--
+	}
+
+	for ; st+2 < len(p.ns); st++ {
+		if st > p.ident && isHeader(p.ns[st]) {
+			// end of program unit
+			break
 		}
+		if p.ns[st].tok != ftNewLine || p.ns[st+1].tok != token.INT ||
+			!bytes.Equal(p.ns[st+1].b, label) || p.ns[st+2].tok != ftFormat {
+			continue
+		}
+		for i := st + 3; i < len(p.ns) && p.ns[i].tok != ftNewLine; i++ {
+			fs = append(fs, p.ns[i])
+		}
+		p.formats[string(label)] = fs
+		return
 	}
-	return "\"" + s + "\\n\""
+
+	p.addError("Cannot found label :" + string(label))
+	return
 }
 
 // Example:
 //  READ ( NIN , FMT = * ) TSTERR
-//  READ ( NIN , FMT = * ) THRESH
 //  READ ( NIN , FMT = * ) ( IDIM ( I ) , I = 1 , NIDIM )
+//  READ ( NIN , FMT = '(A72)' , END = 140 ) ALINE
+//  READ ( 5 , 100 , ERR = 10 , IOSTAT = IOS ) N
+//  READ *, N
+//  READ 100, N
 func (p *parser) parseRead() (stmts []goast.Stmt) {
-	return p.convertLineToComment()
-
 	p.expect(ftRead)
-	p.ns[p.ident].tok = ftWrite
+	p.ident++
 
-	stmts = p.parseStmt()
+	var specs map[string][]node
+	if p.ns[p.ident].tok == token.LPAREN {
+		// READ ( 5, * ) R
+		//      ========= this out
+		args, end := separateArgsParen(p.ns[p.ident:])
+		p.ident += end
+		specs = controlList(args)
+		if p.ns[p.ident].tok == token.COMMA {
+			p.ident++
+		}
+	} else {
+		// READ *, N
+		//      = format
+		var format []node
+		for ; p.ns[p.ident].tok != ftNewLine && p.ns[p.ident].tok != token.COMMA; p.ident++ {
+			format = append(format, p.ns[p.ident])
+		}
+		if p.ns[p.ident].tok == token.COMMA {
+			p.ident++
+		}
+		specs = map[string][]node{
+			"UNIT": {{tok: token.INT, b: []byte("5")}},
+			"FMT":  format,
+		}
+	}
 
-	if e, ok := stmts[0].(*goast.ExprStmt); ok {
-		if c, ok := e.X.(*goast.CallExpr); ok {
-			if sel, ok := c.Fun.(*goast.SelectorExpr); ok {
-				sel.Sel.Name = "READ"
-			}
+	unit, ok := specs["UNIT"]
+	if !ok {
+		panic(fmt.Errorf("READ without UNIT: %s", p.getLine()))
+	}
+
+	// input list
+	var list []node
+	for ; p.ns[p.ident].tok != ftNewLine; p.ident++ {
+		list = append(list, p.ns[p.ident])
+	}
+
+	if nml, ok := p.namelist(specs); ok {
+		// namelist input
+		// Example:
+		//  READ ( 5 , NML = PARAMS , END = 10 )
+		return p.ioCall("READNML", specs, p.parseRecord(unit, specs), nml)
+	}
+
+	format, ok := specs["FMT"]
+	if !ok {
+		// unformatted input
+		// Example:
+		//  READ ( IOS ) NSTEP , TTIM , ( U ( I ) , I = 1 , NNEQ )
+		return p.ioCall("READU", specs, append([]goast.Expr{
+			p.parseRecord(unit, specs),
+		}, p.parseInputList(list)...)...)
+	}
+
+	return p.ioCall("READ", specs, append([]goast.Expr{
+		p.parseRecord(unit, specs),
+		p.parseFormat(format),
+	}, p.parseInputList(list)...)...)
+}
+
+// ioCall return statements with call of runtime function of I/O
+// statement. Status of statement is second argument of function and
+// it is checked for specifiers END, ERR. Temporary variable of status
+// is used, if specifier IOSTAT is not present.
+//
+// Example:
+//  READ ( NIN , FMT = '(A80)' , END = 380 ) LINE
+//  OPEN ( NOUT , FILE = SUMMRY , IOSTAT = IOS , ERR = 10 )
+//
+// result:
+//
+//	{
+//		var iostat int
+//		intrinsic.READ(NIN, &iostat, []byte("(A80)"), LINE)
+//		if iostat < 0 {
+//			goto Label380
+//		}
+//	}
+//	intrinsic.OPEN(NOUT, IOS, intrinsic.OpenSpec{FILE: SUMMRY})
+//	if (*IOS) > 0 {
+//		goto Label10
+//	}
+func (p *parser) ioCall(name string, specs map[string][]node, args ...goast.Expr) (stmts []goast.Stmt) {
+	var iostat goast.Expr = goast.NewIdent("nil")
+	var value goast.Expr
+	if ios, ok := specs["IOSTAT"]; ok {
+		value = p.parseExprNodes(ios)
+		iostat = &goast.UnaryExpr{Op: token.AND, X: value}
+	}
+	var branches []goast.Stmt
+	for _, b := range []struct {
+		spec string
+		op   token.Token
+	}{
+		{"END", token.LSS},
+		{"ERR", token.GTR},
+	} {
+		label, ok := specs[b.spec]
+		if !ok {
+			continue
+		}
+		if value == nil {
+			// temporary variable for status
+			value = goast.NewIdent("iostat")
+			iostat = &goast.UnaryExpr{Op: token.AND, X: value}
+			stmts = append(stmts, &goast.DeclStmt{Decl: &goast.GenDecl{
+				Tok: token.VAR,
+				Specs: []goast.Spec{&goast.ValueSpec{
+					Names: []*goast.Ident{goast.NewIdent("iostat")},
+					Type:  goast.NewIdent("int"),
+				}},
+			}})
 		}
+		labelName := "Label" + string(label[0].b)
+		p.foundLabels[labelName] = true
+		branches = append(branches, &goast.IfStmt{
+			Cond: &goast.BinaryExpr{X: value, Op: b.op, Y: goast.NewIdent("0")},
+			Body: &goast.BlockStmt{List: []goast.Stmt{&goast.BranchStmt{
+				Tok:   token.GOTO,
+				Label: goast.NewIdent(labelName),
+			}}},
+		})
 	}
 
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	stmts = append(stmts, &goast.ExprStmt{
+		X: &goast.CallExpr{
+			Fun: &goast.SelectorExpr{
+				X:   goast.NewIdent("intrinsic"),
+				Sel: goast.NewIdent(name),
+			},
+			Args: append([]goast.Expr{args[0], iostat}, args[1:]...),
+		},
+	})
+	stmts = append(stmts, branches...)
+	if _, ok := specs["IOSTAT"]; !ok && len(branches) > 0 {
+		// temporary variable is in block
+		stmts = []goast.Stmt{&goast.BlockStmt{List: stmts}}
+	}
 	return
 }
 
@@ -301,98 +649,176 @@ func (p *parser) parseRead() (stmts []goast.Stmt) {
 //  OPEN ( NTRA , FILE = SNAPS )
 //  OPEN ( NOUT , FILE = SUMMRY , STATUS = 'UNKNOWN' )
 //  OPEN ( UNIT = 2 , FILE = "./testdata/main.f" )
+//  OPEN ( 10 , STATUS = 'SCRATCH' , FORM = 'UNFORMATTED' , IOSTAT = IOS , ERR = 100 )
 func (p *parser) parseOpen() (stmts []goast.Stmt) {
-	return p.convertLineToComment()
-
 	p.expect(ftOpen)
 	p.ident++
 	p.expect(token.LPAREN)
 	args, end := separateArgsParen(p.ns[p.ident:])
 	p.ident += end
+	specs := controlList(args)
 
-	// Pattern:
-	//  OPEN( UNIT = ..., FILE = ...)
-	// Other parameters are ignored
-
-	// Part : UNIT
-	unit := string(args[0][0].b)
-	if len(args[0]) == 3 {
-		unit = string(args[0][2].b)
+	unit, ok := specs["UNIT"]
+	if !ok {
+		panic(fmt.Errorf("OPEN without UNIT: %s", p.getLine()))
 	}
-
-	// Part : FILE
-	file := string(args[1][0].b)
-	if len(args[1]) == 3 {
-		file = string(args[1][2].b)
+	if name, ok := specs["NAME"]; ok {
+		// NAME is alias of FILE
+		specs["FILE"] = name
 	}
 
-	s := fmt.Sprintf("intrinsic.OPEN(%s,%s)", unit, file)
-	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-	ast, err := goparser.ParseExpr(s)
-	if err != nil {
-		panic(fmt.Errorf("%s:%s", s, err))
+	// specifiers of connection, other specifiers are ignored
+	var elts []goast.Expr
+	for _, name := range []string{
+		"FILE", "STATUS", "ACCESS", "FORM", "ACTION",
+		"POSITION", "BLANK", "DELIM", "PAD", "RECL",
+	} {
+		spec, ok := specs[name]
+		if !ok {
+			continue
+		}
+		elts = append(elts, &goast.KeyValueExpr{
+			Key:   goast.NewIdent(name),
+			Value: p.parseCharacter(spec),
+		})
 	}
-	stmts = append(stmts, &goast.ExprStmt{
-		X: ast,
-	})
 
-	return
+	return p.ioCall("OPEN", specs, p.parseUnit(unit), &goast.CompositeLit{
+		Type: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent("OpenSpec"),
+		},
+		Elts: elts,
+	})
 }
 
 // Example:
 //  CLOSE ( 2 )
 //  CLOSE ( NIN )
+//  CLOSE ( UNIT = NTRA , STATUS = 'DELETE' , IOSTAT = IOS )
 func (p *parser) parseClose() (stmts []goast.Stmt) {
 	p.expect(ftClose)
 	p.ident++
 	p.expect(token.LPAREN)
 	args, end := separateArgsParen(p.ns[p.ident:])
 	p.ident += end
+	specs := controlList(args)
 
-	s := fmt.Sprintf("intrinsic.CLOSE(%s)", string(args[0][0].b))
-	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
-	ast, err := goparser.ParseExpr(s)
-	if err != nil {
-		panic(err)
+	unit, ok := specs["UNIT"]
+	if !ok {
+		panic(fmt.Errorf("CLOSE without UNIT: %s", p.getLine()))
 	}
-	stmts = append(stmts, &goast.ExprStmt{
-		X: ast,
+	var status goast.Expr = goast.NewIdent("nil")
+	if st, ok := specs["STATUS"]; ok {
+		status = p.parseCharacter(st)
+	}
+	return p.ioCall("CLOSE", specs, p.parseUnit(unit), status)
+}
+
+// Example:
+//  INQUIRE ( FILE = FRESX , EXIST = EXST )
+//  INQUIRE ( UNIT = IOS , OPENED = LOPEN , NAME = FNAME )
+//
+// result:
+//
+//	intrinsic.INQUIRE((*FRESX), nil, intrinsic.InquireSpec{EXIST: &(*EXST)})
+func (p *parser) parseInquire() (stmts []goast.Stmt) {
+	p.expect(ftInquire)
+	p.ident++
+	p.expect(token.LPAREN)
+	args, end := separateArgsParen(p.ns[p.ident:])
+	p.ident += end
+	specs := controlList(args)
+
+	var unit goast.Expr
+	if file, ok := specs["FILE"]; ok {
+		unit = p.parseCharacter(file)
+	} else if u, ok := specs["UNIT"]; ok {
+		unit = p.parseUnit(u)
+	} else {
+		panic(fmt.Errorf("INQUIRE without UNIT or FILE: %s", p.getLine()))
+	}
+
+	// specifiers for result, other specifiers are ignored
+	var elts []goast.Expr
+	for _, name := range []string{
+		"EXIST", "OPENED", "NUMBER", "NAMED", "NAME", "ACCESS", "FORM", "SIZE",
+	} {
+		spec, ok := specs[name]
+		if !ok {
+			continue
+		}
+		value := p.parseExprNodes(spec)
+		switch name {
+		case "NAME", "ACCESS", "FORM":
+			// CHARACTER variable is changed in place
+		default:
+			value = &goast.UnaryExpr{Op: token.AND, X: value}
+		}
+		elts = append(elts, &goast.KeyValueExpr{
+			Key:   goast.NewIdent(name),
+			Value: value,
+		})
+	}
+
+	return p.ioCall("INQUIRE", specs, unit, &goast.CompositeLit{
+		Type: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent("InquireSpec"),
+		},
+		Elts: elts,
 	})
+}
 
-	return
+// parseCharacter return expression of value of specifier
+//
+// Example:
+//  'UNKNOWN'
+//  SUMMRY
+//  NREC * 8
+func (p *parser) parseCharacter(spec []node) goast.Expr {
+	if len(spec) == 1 && spec[0].tok == token.STRING {
+		return goast.NewIdent(fmt.Sprintf("[]byte(%s)", spec[0].b))
+	}
+	return p.parseExprNodes(spec)
 }
 
-//  READ  ( NIN , FMT = * ) ( IDIM ( I ) , I = 1 , NIDIM )
-//  WRITE ( NIN , FMT = * ) ( IDIM ( I ) , I = 1 , NIDIM )
-//  WRITE ( NIN , FMT = * ) ( A ( I , J ) , J = 1  ,  NIDIM )
-//  ======================= this is ast
-//                          ==============================
-//                           this is return ForStmt
+// createForArguments return loop of implied-DO list
 //
-//                I = 1  NIDIM
-//                I = 1  NIDIM
-//                =============== loop
-//                0 1 2  3
-func (p parser) createForArguments(loop []node, ast goast.Expr) (f goast.ForStmt) {
-	return goast.ForStmt{
+//  ( A ( I , J ) , J = 1  ,  NIDIM , 2 )
+//                  ======   ======   =
+//                  0        1        2
+func (p *parser) createForArguments(loop [][]node, body goast.Stmt) *goast.ForStmt {
+	name := loop[0][0]
+	f := &goast.ForStmt{
 		Init: &goast.AssignStmt{
-			Lhs: []goast.Expr{goast.NewIdent(string(loop[0].b))},
+			Lhs: []goast.Expr{p.parseExprNodes([]node{name})},
 			Tok: token.ASSIGN,
-			Rhs: []goast.Expr{p.parseExprNodes([]node{loop[2]})},
+			Rhs: []goast.Expr{p.parseExprNodes(loop[0][2:])},
 		},
-		Cond: p.parseExprNodes([]node{
-			loop[0],
+		Cond: p.parseExprNodes(append([]node{
+			name,
 			{tok: token.LEQ, b: []byte("<=")},
-			loop[3],
-		}),
+		}, loop[1]...)),
 		Post: &goast.IncDecStmt{
-			X:   goast.NewIdent(string(loop[0].b)),
+			X:   p.parseExprNodes([]node{name}),
 			Tok: token.INC,
 		},
-		Body: &goast.BlockStmt{
-			List: []goast.Stmt{
-				&goast.ExprStmt{X: ast},
-			},
-		},
+		Body: &goast.BlockStmt{List: []goast.Stmt{body}},
+	}
+	if len(loop) == 3 {
+		if loop[2][0].tok == token.SUB {
+			// negative step
+			f.Cond = p.parseExprNodes(append([]node{
+				name,
+				{tok: token.GEQ, b: []byte(">=")},
+			}, loop[1]...))
+		}
+		f.Post = &goast.AssignStmt{
+			Lhs: []goast.Expr{p.parseExprNodes([]node{name})},
+			Tok: token.ADD_ASSIGN,
+			Rhs: []goast.Expr{p.parseExprNodes(loop[2])},
+		}
 	}
+	return f
 }
@@ -0,0 +1,276 @@
+package fortran
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/format"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// parseIO return Go source of fortran source after changes of passes
+func parseIO(t *testing.T, src string, passes ...func([]*goast.File)) string {
+	return parseFiles(t, []string{src}, passes...)[0]
+}
+
+// parseFiles return Go sources of fortran sources of one package after
+// changes of passes
+func parseFiles(t *testing.T, srcs []string, passes ...func([]*goast.File)) []string {
+	var files []*goast.File
+	for _, src := range srcs {
+		ast, errs := Parse([]byte(src), "main")
+		if len(errs) > 0 {
+			t.Fatalf("%v", errs)
+		}
+		files = append(files, &ast)
+	}
+	for _, pass := range passes {
+		pass(files)
+	}
+	out := make([]string, len(files))
+	for i, f := range files {
+		var buf bytes.Buffer
+		if err := format.Node(&buf, token.NewFileSet(), f); err != nil {
+			t.Fatal(err)
+		}
+		out[i] = buf.String()
+	}
+	return out
+}
+
+func TestWrite(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE OUT(N, A, S)
+      INTEGER N, A(*), I
+      CHARACTER*8 S
+  100 FORMAT ('Values: ', 5I3)
+      WRITE(*,100) (A(I), I=1,N)
+      WRITE(6,FMT=200) N, 'it''s'
+      WRITE(UNIT=N,FMT='(I3)') N
+      WRITE(*,S) A(1)
+      WRITE(*,*) N
+      WRITE(N, 200, IOSTAT = I, ERR = 300) N
+      WRITE(S, '(I8)', END = 300) N
+  200 FORMAT (1PE12.4, ' n=', I2, A)
+  300 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.WRITE(6, nil, []byte("('Values: ',5I3)"), func() (items []interface{}) {`,
+		`for (*I) = 1; (*I) <= (*(N)); (*I)++ {`,
+		`items = append(items, (*(A))[(*I)-(1)])`,
+		`intrinsic.WRITE(6, nil, []byte("(1PE12.4,' n=',I2,A)"), (*(N)), []byte("it's"))`,
+		`intrinsic.WRITE((*(N)), nil, []byte("(I3)"), (*(N)))`,
+		`intrinsic.WRITE(6, nil, (*(S)), (*(A))[1-(1)])`,
+		`intrinsic.WRITE(6, nil, nil, (*(N)))`,
+		`intrinsic.WRITE((*(N)), I, []byte("(1PE12.4,' n=',I2,A)"), (*(N)))`,
+		`if (*I) > 0 {
+		goto Label300`,
+		`intrinsic.WRITE((*(S)), &iostat, []byte("(I8)"), (*(N)))`,
+		`if iostat < 0 {
+			goto Label300`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE OUT(N, R)
+      INTEGER N
+      DOUBLE PRECISION R
+      PRINT *, 'N = ', N, R
+      PRINT *
+      PRINT 100, N
+      PRINT '(A,I3)', 'N = ', (N, N = 1, 2)
+  100 FORMAT (I5)
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.WRITE(6, nil, nil, []byte("N = "), (*(N)), (*(R)))`,
+		`intrinsic.WRITE(6, nil, nil)`,
+		`intrinsic.WRITE(6, nil, []byte("(I5)"), (*(N)))`,
+		`intrinsic.WRITE(6, nil, []byte("(A,I3)"), []byte("N = "), func() (items []interface{}) {`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE INP(N, A, S)
+      INTEGER N, A(*), I, IOS
+      CHARACTER*8 S
+      READ(5,*) N
+      READ(5, FMT = * )( A( I ), I = 1, N )
+      READ(5,'(A8)', END = 140) S
+      READ(5, 100, ERR = 150, IOSTAT = IOS) A(2)
+      READ *, N
+  100 FORMAT (I5)
+  140 CONTINUE
+  150 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.READ(5, nil, nil, (N))`,
+		`items = append(items, &(*(A))[(*I)-(1)])`,
+		`var iostat int`,
+		`intrinsic.READ(5, &iostat, []byte("(A8)"), (S))`,
+		`if iostat < 0 {
+			goto Label140`,
+		`intrinsic.READ(5, IOS, []byte("(I5)"), &(*(A))[2-(1)])`,
+		`if (*IOS) > 0 {
+		goto Label150`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestOpenClose(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE FIO(NOUT, SUMMRY)
+      INTEGER NOUT, IOS, N
+      CHARACTER*32 SUMMRY
+      OPEN ( NOUT , FILE = SUMMRY , STATUS = 'UNKNOWN' )
+      OPEN ( 10 , STATUS = 'SCRATCH' , IOSTAT = IOS , ERR = 100, RECL = N*8 )
+      CLOSE ( UNIT = 10 , STATUS = 'DELETE' , ERR = 100 )
+  100 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.OPEN((*(NOUT)), nil, intrinsic.OpenSpec{FILE: (*(SUMMRY)), STATUS: []byte("UNKNOWN")})`,
+		`intrinsic.OPEN(10, IOS, intrinsic.OpenSpec{STATUS: []byte("SCRATCH"), RECL: (*N) * 8})`,
+		`if (*IOS) > 0 {
+		goto Label100`,
+		`intrinsic.CLOSE(10, &iostat, []byte("DELETE"))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestUnformatted(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE RESTRT(IOS, N, U, TTIM)
+      INTEGER IOS, N, I
+      DOUBLE PRECISION U(*), TTIM
+      WRITE(IOS) N, TTIM, (U(I), I = 1, N)
+      READ(IOS, END = 100) N, TTIM
+  100 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.WRITEU((*(IOS)), nil, (*(N)), (*(TTIM))`,
+		`intrinsic.READU((*(IOS)), &iostat, (N), (TTIM))`,
+		`if iostat < 0 {
+			goto Label100`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestDirect(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE OUTCOR(K, A, N)
+      INTEGER K, N
+      DOUBLE PRECISION A(N)
+      OPEN(10, FILE = 'a.bin', ACCESS = 'DIRECT', RECL = 8*N)
+      WRITE(10, REC = K) A
+      READ(10, REC = K + 1, ERR = 100) A
+      WRITE(11, '(3I5)', REC = 2) N
+  100 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`ACCESS: []byte("DIRECT"), RECL: 8 * (*(N))`,
+		`intrinsic.WRITEU(intrinsic.Record{Unit: 10, REC: (*(K))}, nil, (*(A)))`,
+		`intrinsic.READU(intrinsic.Record{Unit: 10, REC: (*(K)) + 1}, &iostat, (A))`,
+		`intrinsic.WRITE(intrinsic.Record{Unit: 11, REC: 2}, nil, []byte("(3I5)"), (*(N)))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestInternal(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE CNV(STR, X, N)
+      CHARACTER*(*) STR
+      CHARACTER*10 LINE
+      DOUBLE PRECISION X
+      INTEGER N
+      WRITE(STR, '(I5)') N
+      READ(LINE, *, ERR = 100) X
+  100 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.WRITE((*(STR)), nil, []byte("(I5)"), (*(N)))`,
+		`intrinsic.READ((*LINE), &iostat, nil, (X))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestInquire(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE SAV(IOS, FRESX, LFILE)
+      INTEGER IOS, LFILE, N
+      CHARACTER*(*) FRESX
+      CHARACTER*20 FNAME
+      LOGICAL EXST, LOPEN
+      INQUIRE(FILE = FRESX, EXIST = EXST)
+      INQUIRE(UNIT = IOS, OPENED = LOPEN, NAME = FNAME, ERR = 100)
+      REWIND LFILE
+      BACKSPACE (UNIT = LFILE, IOSTAT = N)
+      END FILE 10
+  100 CONTINUE
+      END
+`)
+	for _, s := range []string{
+		`intrinsic.INQUIRE((*(FRESX)), nil, intrinsic.InquireSpec{EXIST: &(*EXST)})`,
+		`intrinsic.INQUIRE((*(IOS)), &iostat, intrinsic.InquireSpec{OPENED: &(*LOPEN), NAME: (*FNAME)})`,
+		`intrinsic.REWIND((*(LFILE)), nil)`,
+		`intrinsic.BACKSPACE((*(LFILE)), N)`,
+		`intrinsic.ENDFILE(10, nil)`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestNamelist(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE INP(NIN)
+      INTEGER NIN, N
+      REAL*8 X(3)
+      NAMELIST /PARAMS/ N, X
+      READ(NIN, NML = PARAMS, END = 100)
+      WRITE(*, PARAMS)
+  100 CONTINUE
+      END
+`)
+	nml := `intrinsic.Namelist{Name: "PARAMS", Names: []string{"N", "X"}, Items: []interface{}{&(*N), &(*X)}}`
+	for _, s := range []string{
+		`intrinsic.READNML((*(NIN)), &iostat, ` + nml + `)`,
+		`intrinsic.WRITENML(6, nil, ` + nml + `)`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package fortran
+
+import (
+	goast "go/ast"
+	"strings"
+)
+
+func init() {
+	for _, group := range []struct {
+		names  []string
+		args   []string
+		result string
+	}{
+		{names: []string{"EPSILON", "TINY", "HUGE", "SPACING", "FRACTION"},
+			args: []string{any}, result: any},
+		{names: []string{"DIGITS", "RADIX", "PRECISION", "RANGE",
+			"MAXEXPONENT", "MINEXPONENT", "EXPONENT"},
+			args: []string{any}, result: "int"},
+		{names: []string{"NEAREST"}, args: []string{any, "float64"}, result: any},
+		{names: []string{"SCALE"}, args: []string{any, "int"}, result: any},
+	} {
+		for _, name := range group.names {
+			specificFunctions[name] = specific{
+				args:   group.args,
+				result: group.result,
+			}
+			intrinsicFunction[name] = modelIntrinsic
+		}
+	}
+}
+
+// modelIntrinsic change call of numeric inquiry or manipulation
+// function. REAL of single precision is float64 in Go, so value is
+// converted to float32 for model of single precision.
+//
+// Example:
+//  EPSILON(X)  - float64(intrinsic.EPSILON(float32((*X))))
+//  DIGITS(X)   - intrinsic.DIGITS(float32((*X)))
+//  HUGE(D)     - intrinsic.HUGE((*D))
+func modelIntrinsic(p *parser, f *goast.CallExpr) {
+	name := strings.ToUpper(f.Fun.(*goast.Ident).Name)
+	numericIntrinsic(p, f)
+	if _, ok := f.Fun.(*goast.SelectorExpr); !ok || len(f.Args) == 0 ||
+		!p.isSingle(f.Args[0]) {
+		return
+	}
+	f.Args[0] = &goast.CallExpr{
+		Fun:  goast.NewIdent("float32"),
+		Args: []goast.Expr{f.Args[0]},
+	}
+	if specificFunctions[name].result != any {
+		return
+	}
+	call := *f
+	f.Fun, f.Args = goast.NewIdent("float64"), []goast.Expr{&call}
+}
+
+// isSingle return true for value of REAL of single precision
+func (p *parser) isSingle(e goast.Expr) bool {
+	switch e := e.(type) {
+	case *goast.Ident:
+		name := strings.TrimLeft(strings.TrimRight(e.Name, ")"), "(*")
+		if v, ok := p.initVars.get(name); ok {
+			return v.typ.single
+		}
+		if typ, ok := p.arguments[name]; ok {
+			return typ.single
+		}
+	case *goast.ParenExpr:
+		return p.isSingle(e.X)
+	case *goast.StarExpr:
+		return p.isSingle(e.X)
+	case *goast.IndexExpr:
+		return p.isSingle(e.X)
+	case *goast.UnaryExpr:
+		return p.isSingle(e.X)
+	}
+	return false
+}
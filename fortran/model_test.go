@@ -0,0 +1,32 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModelIntrinsic(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE MODEL(X, D, N)
+      REAL X
+      DOUBLE PRECISION D
+      INTEGER N
+      X = EPSILON(X) * RADIX(X)
+      D = HUGE(D) / 2 + SPACING(D)
+      N = DIGITS(X) + MAXEXPONENT(D)
+      X = NEAREST(X, -1.0) + SCALE(X, N)
+      N = HUGE(N)
+      END
+`)
+	for _, s := range []string{
+		`(*(X)) = float64(intrinsic.EPSILON(float32((*(X))))) * float64(intrinsic.RADIX(float32((*(X)))))`,
+		`(*(D)) = intrinsic.HUGE((*(D)))/2 + intrinsic.SPACING((*(D)))`,
+		`(*(N)) = intrinsic.DIGITS(float32((*(X)))) + intrinsic.MAXEXPONENT((*(D)))`,
+		`(*(X)) = float64(intrinsic.NEAREST(float32((*(X))), -1.0)) + float64(intrinsic.SCALE(float32((*(X))), (*(N))))`,
+		`(*(N)) = intrinsic.HUGE((*(N)))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
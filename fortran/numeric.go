@@ -0,0 +1,456 @@
+package fortran
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// specific is specific intrinsic function of FORTRAN 77
+type specific struct {
+	args     []string // types of arguments, empty type is type of arguments
+	variadic bool     // last argument is repeated
+	optional int      // amount of optional last arguments
+	result   string   // type of result, ANY is type of first argument
+}
+
+// specificFunctions is specific numeric intrinsic functions of
+// FORTRAN 77 in package intrinsic by names
+var specificFunctions = map[string]specific{}
+
+func init() {
+	for _, group := range []struct {
+		names    []string
+		args     []string
+		variadic bool
+		result   string
+	}{
+		{names: []string{"INT", "IFIX", "IDINT", "NINT", "IDNINT"},
+			args: []string{"float64"}, result: "int"},
+		{names: []string{"FLOAT"}, args: []string{"int"}, result: "float64"},
+		{names: []string{"IABS"}, args: []string{"int"}, result: "int"},
+		{names: []string{"MOD", "ISIGN", "IDIM"},
+			args: []string{"int", "int"}, result: "int"},
+		{names: []string{"SNGL", "AINT", "DINT", "ANINT", "DNINT", "DABS",
+			"DSQRT", "EXP", "DEXP", "ALOG", "DLOG", "ALOG10", "DLOG10",
+			"SIN", "DSIN", "COS", "DCOS", "TAN", "DTAN",
+			"ASIN", "DASIN", "ACOS", "DACOS", "ATAN", "DATAN",
+			"SINH", "DSINH", "COSH", "DCOSH", "TANH", "DTANH"},
+			args: []string{"float64"}, result: "float64"},
+		{names: []string{"AMOD", "DMOD", "SIGN", "DSIGN", "DIM", "DDIM",
+			"DPROD", "ATAN2", "DATAN2"},
+			args: []string{"float64", "float64"}, result: "float64"},
+		{names: []string{"CABS"}, args: []string{"complex128"}, result: "float64"},
+		{names: []string{"CSQRT", "CEXP", "CLOG", "CSIN", "CCOS", "CONJG", "DCONJG"},
+			args: []string{"complex128"}, result: "complex128"},
+		{names: []string{"MAX0", "MIN0"},
+			args: []string{"int"}, variadic: true, result: "int"},
+		{names: []string{"AMAX1", "DMAX1", "AMIN1", "DMIN1"},
+			args: []string{"float64"}, variadic: true, result: "float64"},
+		{names: []string{"AMAX0", "AMIN0"},
+			args: []string{"int"}, variadic: true, result: "float64"},
+		{names: []string{"MAX1", "MIN1"},
+			args: []string{"float64"}, variadic: true, result: "int"},
+		{names: []string{"CMPLX"},
+			args: []string{""}, variadic: true, result: "complex128"},
+	} {
+		for _, name := range group.names {
+			specificFunctions[name] = specific{
+				args:     group.args,
+				variadic: group.variadic,
+				result:   group.result,
+			}
+			intrinsicFunction[name] = numericIntrinsic
+		}
+	}
+	for name := range genericFunctions {
+		intrinsicFunction[name] = numericIntrinsic
+	}
+}
+
+// genericFunctions is generic numeric intrinsic functions of FORTRAN 77
+// with specific function for each type of arguments. Specific function
+// in lower case is conversion of Go.
+var genericFunctions = map[string]map[string]string{
+	"INT":   {"int": "int", "float64": "INT", "complex128": "INT"},
+	"REAL":  {"int": "FLOAT", "float64": "float64", "complex128": "real"},
+	"DBLE":  {"int": "float64", "float64": "float64", "complex128": "real"},
+	"AINT":  {"float64": "AINT"},
+	"ANINT": {"float64": "ANINT"},
+	"NINT":  {"float64": "NINT"},
+	"ABS":   {"int": "IABS", "float64": "DABS", "complex128": "CABS"},
+	"MOD":   {"int": "MOD", "float64": "DMOD"},
+	"SIGN":  {"int": "ISIGN", "float64": "SIGN"},
+	"DIM":   {"int": "IDIM", "float64": "DIM"},
+	"MAX":   {"int": "MAX0", "float64": "DMAX1"},
+	"MIN":   {"int": "MIN0", "float64": "DMIN1"},
+	"SQRT":  {"float64": "DSQRT", "complex128": "CSQRT"},
+	"EXP":   {"float64": "EXP", "complex128": "CEXP"},
+	"LOG":   {"float64": "ALOG", "complex128": "CLOG"},
+	"LOG10": {"float64": "ALOG10"},
+	"SIN":   {"float64": "SIN", "complex128": "CSIN"},
+	"COS":   {"float64": "COS", "complex128": "CCOS"},
+	"TAN":   {"float64": "TAN"},
+	"ASIN":  {"float64": "ASIN"},
+	"ACOS":  {"float64": "ACOS"},
+	"ATAN":  {"float64": "ATAN"},
+	"ATAN2": {"float64": "ATAN2"},
+	"SINH":  {"float64": "SINH"},
+	"COSH":  {"float64": "COSH"},
+	"TANH":  {"float64": "TANH"},
+	"CMPLX": {"int": "CMPLX", "float64": "CMPLX", "complex128": "complex128"},
+	"AIMAG": {"complex128": "imag"},
+
+	// DOUBLE COMPLEX functions of common extension
+	"DCMPLX": {"int": "CMPLX", "float64": "CMPLX", "complex128": "complex128"},
+	"CDABS":  {"complex128": "CABS"},
+	"DIMAG":  {"complex128": "imag"},
+}
+
+// numericIntrinsic change call of numeric or character intrinsic
+// function to call of specific function of package intrinsic. Generic
+// function is changed by type of arguments, arguments are values.
+//
+// Example:
+//  MAX(N, 3, M)     - intrinsic.MAX0((*(N)), 3, (*M))
+//  SIGN(X, -1.0D0)  - intrinsic.SIGN((*(X)), -1.0e0)
+//  REAL(I)          - intrinsic.FLOAT((*I))
+//  DBLE(I)          - float64((*I))
+//  ABS(CONJG(A))    - intrinsic.CABS(intrinsic.CONJG((*(A))))
+//  AIMAG(A)         - imag((*(A)))
+func numericIntrinsic(p *parser, f *goast.CallExpr) {
+	id, ok := f.Fun.(*goast.Ident)
+	if !ok || id.Name != strings.ToUpper(id.Name) {
+		// names of Fortran are in upper case, but conversions of Go
+		// are in lower case
+		return
+	}
+	name := id.Name
+
+	args := make([]goast.Expr, len(f.Args))
+	types := make([]string, len(f.Args))
+	var typ string
+	for i := range f.Args {
+		args[i], types[i] = p.intrinsicArgument(f.Args[i])
+		typ = promoteType(typ, types[i])
+	}
+
+	if generic, ok := genericFunctions[name]; ok {
+		if typ == "" {
+			typ = "float64"
+			if _, ok := generic[typ]; !ok {
+				// function only of COMPLEX arguments
+				typ = "complex128"
+			}
+		}
+		if name, ok = generic[typ]; !ok {
+			p.addError(fmt.Sprintf("Not valid type %s of arguments for %s", typ, id.Name))
+			return
+		}
+	}
+
+	s, ok := specificFunctions[name]
+	if !ok {
+		// conversion of Go
+		s = specific{args: []string{typ}, result: name}
+		if name == "real" {
+			s.result = "float64"
+		}
+	}
+	if len(args) < len(s.args)-s.optional || (!s.variadic && len(args) > len(s.args)) {
+		p.addError(fmt.Sprintf("Not valid amount of arguments for %s: %d", id.Name, len(args)))
+		return
+	}
+
+	// conversion of arguments
+	for i := range args {
+		t := s.args[len(s.args)-1]
+		if i < len(s.args) {
+			t = s.args[i]
+		}
+		if t == any {
+			// type parameter of generic function
+			continue
+		}
+		if t == "" {
+			t = typ
+		}
+		if t == "[]byte" {
+			args[i] = characterValue(args[i], types[i])
+			continue
+		}
+		if types[i] == "" || isConstant(args[i]) {
+			continue
+		}
+		args[i] = convert(args[i], types[i], t)
+	}
+
+	f.Fun, f.Args = goast.NewIdent(name), args
+	if _, ok := specificFunctions[name]; ok {
+		p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+		f.Fun = &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent(name),
+		}
+	}
+}
+
+// assignConversion add conversion of result of numeric intrinsic
+// function to type of variable
+//
+// Example:
+//  X = MAX(I, 5)  - (*X) = float64(intrinsic.MAX0((*I), 5))
+func (p *parser) assignConversion(a *goast.AssignStmt) {
+	call := a.Rhs[0]
+	if !isSpecificCall(call) {
+		return
+	}
+	typ, r := p.typeOf(a.Lhs[0]), kind(p.typeOf(call))
+	if k := kind(typ); (k != "int" && k != "float64") || (r != "int" && r != "float64") || k == r {
+		return
+	}
+	a.Rhs[0] = &goast.CallExpr{Fun: goast.NewIdent(typ), Args: []goast.Expr{call}}
+}
+
+// binaryConversion add conversion of INTEGER result of numeric
+// intrinsic function in operation with REAL value
+//
+// Example:
+//  EPS * RADIX(X)  - (*EPS) * float64(intrinsic.RADIX((*X)))
+func (p *parser) binaryConversion(be *goast.BinaryExpr) {
+	x, y := &be.X, &be.Y
+	for i := 0; i < 2; i++ {
+		if isSpecificCall(*x) && kind(p.typeOf(*x)) == "int" && p.typeOf(*y) == "float64" {
+			*x = &goast.CallExpr{Fun: goast.NewIdent("float64"), Args: []goast.Expr{*x}}
+		}
+		x, y = y, x
+	}
+}
+
+// isSpecificCall return true for call of specific intrinsic function
+func isSpecificCall(e goast.Expr) bool {
+	call, ok := e.(*goast.CallExpr)
+	if !ok {
+		return false
+	}
+	fun := call.Fun
+	if ix, ok := fun.(*goast.IndexExpr); ok {
+		// only result without rank
+		if id, ok := ix.Index.(*goast.Ident); !ok || strings.HasPrefix(id.Name, "[]") {
+			return false
+		}
+		fun = ix.X
+	}
+	sel, ok := fun.(*goast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	if x, ok := sel.X.(*goast.Ident); !ok || x.Name != "intrinsic" {
+		return false
+	}
+	_, ok = specificFunctions[sel.Sel.Name]
+	return ok
+}
+
+// constantArgument is argument with constant value
+//
+// Example:
+//  func()*int{y:=3;return &y}()
+//  func()*intrinsic.Character{y:=intrinsic.Character("ABC");return &y}()
+var constantArgument = regexp.MustCompile(`^func\(\)\*([\w.\[\]]+)\{y:=(.*);return &y\}\(\)$`)
+
+// intrinsicArgument return value and type of argument
+func (p *parser) intrinsicArgument(arg goast.Expr) (goast.Expr, string) {
+	switch a := arg.(type) {
+	case *goast.UnaryExpr:
+		// from : &((*(X)))
+		// to   : ((*(X)))
+		if a.Op == token.AND {
+			arg = a.X
+		}
+	case *goast.Ident:
+		if m := constantArgument.FindStringSubmatch(a.Name); m != nil {
+			if m[1] == character {
+				// from : intrinsic.Character("ABC")
+				// to   : "ABC"
+				lit := &goast.BasicLit{Kind: token.STRING,
+					Value: m[2][len(character)+1 : len(m[2])-1]}
+				return lit, p.typeOf(lit)
+			}
+			return goast.NewIdent(m[2]), m[1]
+		}
+		if len(a.Name) > 3 && a.Name[:2] == "&(" {
+			arg = goast.NewIdent(a.Name[2 : len(a.Name)-1])
+		}
+	case *goast.CallExpr:
+		// from : func() *float64 { y := F(...); return &y }()
+		// to   : F(...)
+		if fl, ok := a.Fun.(*goast.FuncLit); ok && len(fl.Body.List) == 2 {
+			if as, ok := fl.Body.List[0].(*goast.AssignStmt); ok && len(as.Rhs) == 1 {
+				arg = as.Rhs[0]
+			}
+		}
+	}
+	return arg, p.typeOf(arg)
+}
+
+// isConstant return true for untyped constant
+func isConstant(e goast.Expr) bool {
+	switch e := e.(type) {
+	case *goast.BasicLit:
+		return true
+	case *goast.Ident:
+		_, errInt := strconv.Atoi(e.Name)
+		_, errFloat := strconv.ParseFloat(e.Name, 64)
+		return errInt == nil || errFloat == nil
+	case *goast.ParenExpr:
+		return isConstant(e.X)
+	case *goast.UnaryExpr:
+		return isConstant(e.X)
+	}
+	return false
+}
+
+// kind return integer type "int" for all integer types
+func kind(typ string) string {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "byte":
+		return "int"
+	}
+	return typ
+}
+
+// promoteType return type of result of arithmetic operation
+func promoteType(a, b string) string {
+	rank := map[string]int{"int": 1, "float64": 2, "complex128": 3}
+	if rank[kind(a)] < rank[kind(b)] {
+		return kind(b)
+	}
+	return kind(a)
+}
+
+// typeOf return Go type of expression or empty string, if type is
+// not found
+func (p *parser) typeOf(e goast.Expr) string {
+	switch e := e.(type) {
+	case *goast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			if len(e.Value) == 3 {
+				return "byte"
+			}
+			return "[]byte"
+		case token.CHAR:
+			return "byte"
+		case token.INT:
+			return "int"
+		case token.FLOAT:
+			return "float64"
+		case token.IMAG:
+			return "complex128"
+		}
+	case *goast.Ident:
+		switch e.Name {
+		case "true", "false":
+			return "bool"
+		}
+		if _, err := strconv.Atoi(e.Name); err == nil {
+			return "int"
+		}
+		if _, err := strconv.ParseFloat(e.Name, 64); err == nil {
+			return "float64"
+		}
+		name := strings.TrimLeft(strings.TrimRight(e.Name, ")"), "(*")
+		if v, ok := p.initVars.get(name); ok {
+			return characterType(v.typ)
+		}
+		if typ, ok := p.arguments[name]; ok {
+			return characterType(typ)
+		}
+	case *goast.ParenExpr:
+		return p.typeOf(e.X)
+	case *goast.StarExpr:
+		return p.typeOf(e.X)
+	case *goast.IndexExpr:
+		// CHARACTER*n is []byte
+		return strings.TrimPrefix(p.typeOf(e.X), "[]")
+	case *goast.SliceExpr:
+		return p.typeOf(e.X)
+	case *goast.CompositeLit:
+		if id, ok := e.Type.(*goast.Ident); ok {
+			return id.Name
+		}
+	case *goast.UnaryExpr:
+		if e.Op == token.NOT {
+			return "bool"
+		}
+		return p.typeOf(e.X)
+	case *goast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ,
+			token.LAND, token.LOR:
+			return "bool"
+		}
+		return promoteType(p.typeOf(e.X), p.typeOf(e.Y))
+	case *goast.CallExpr:
+		if ix, ok := e.Fun.(*goast.IndexExpr); ok {
+			// type of result is type parameter:
+			// intrinsic.SUM[[]float64](...)
+			if id, ok := ix.Index.(*goast.Ident); ok {
+				return strings.TrimLeft(id.Name, "[]")
+			}
+			break
+		}
+		if sel, ok := e.Fun.(*goast.SelectorExpr); ok {
+			if x, ok := sel.X.(*goast.Ident); ok && x.Name == "intrinsic" {
+				if s, ok := specificFunctions[sel.Sel.Name]; ok {
+					if s.result == any && len(e.Args) > 0 {
+						return p.typeOf(e.Args[0])
+					}
+					return s.result
+				}
+				if sel.Sel.Name == "CONCAT" {
+					return "[]byte"
+				}
+			}
+			break
+		}
+		id, ok := e.Fun.(*goast.Ident)
+		if !ok {
+			break
+		}
+		switch id.Name {
+		case "int", "int8", "int16", "int32", "int64", "byte",
+			"float64", "complex128", "bool":
+			return id.Name
+		case "real", "imag":
+			return "float64"
+		case "complex":
+			return "complex128"
+		case "append":
+			return p.typeOf(e.Args[0])
+		}
+		if isUserFunction(id.Name) {
+			return p.getFunctionType(id.Name).getBaseType()
+		}
+	}
+	return ""
+}
+
+// characterType return type of CHARACTER value with dimensions of
+// arrays or base type for other types
+//
+// Example:
+//  CHARACTER C       - []byte
+//  CHARACTER*8 S     - []byte
+//  CHARACTER*8 A(4)  - [][]byte
+//  REAL*8 X(4)       - float64
+func characterType(typ goType) string {
+	if !typ.isCharacter() {
+		return typ.getBaseType()
+	}
+	return strings.Repeat("[]", len(typ.arrayNode)) + "[]byte"
+}
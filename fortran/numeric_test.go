@@ -0,0 +1,69 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumericIntrinsic(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE NUM(N, X, Z)
+      INTEGER N, I
+      DOUBLE PRECISION X, Y
+      COMPLEX*16 Z
+      I = MAX(N, 3, I)
+      Y = MAX(N, 3)
+      Y = DSIGN(X, -1.0D0)
+      Y = REAL(N) + DBLE(N)
+      I = NINT(ABS(X))
+      Y = ABS(Z) + SQRT(Y)
+      I = MOD(N, 2)
+      Y = MIN(X, N)
+      Z = CMPLX(N, X) + CMPLX(Z)
+      END
+`)
+	for _, s := range []string{
+		`(*I) = intrinsic.MAX0((*(N)), 3, (*I))`,
+		`(*Y) = float64(intrinsic.MAX0((*(N)), 3))`,
+		`(*Y) = intrinsic.DSIGN((*(X)), -1.0e0)`,
+		`(*Y) = intrinsic.FLOAT((*(N))) + float64((*(N)))`,
+		`(*I) = intrinsic.NINT(intrinsic.DABS((*(X))))`,
+		`(*Y) = intrinsic.CABS((*(Z))) + intrinsic.DSQRT((*Y))`,
+		`(*I) = intrinsic.MOD((*(N)), 2)`,
+		`(*Y) = intrinsic.DMIN1((*(X)), float64((*(N))))`,
+		`(*(Z)) = intrinsic.CMPLX(float64((*(N))), (*(X))) + complex128((*(Z)))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
+
+func TestNumericIntrinsicNested(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE CNUM(A, X, N)
+      COMPLEX*16 A
+      DOUBLE PRECISION X
+      INTEGER N
+      X = ABS(CONJG(A)) + ABS(AIMAG(A))
+      X = REAL(CONJG(A)) + REAL(AIMAG(A))
+      X = MAX(AIMAG(A), X, ABS(DCONJG(A)))
+      N = MAX(N, INT(ABS(A)))
+      A = DCMPLX(X, DIMAG(A))
+      X = CDABS(A)
+      END
+`)
+	for _, s := range []string{
+		`(*(X)) = intrinsic.CABS(intrinsic.CONJG((*(A)))) + intrinsic.DABS(imag((*(A))))`,
+		`(*(X)) = real(intrinsic.CONJG((*(A)))) + float64(imag((*(A))))`,
+		`(*(X)) = intrinsic.DMAX1(imag((*(A))), (*(X)), intrinsic.CABS(intrinsic.DCONJG((*(A)))))`,
+		`(*(N)) = intrinsic.MAX0((*(N)), intrinsic.INT(intrinsic.CABS((*(A)))))`,
+		`(*(A)) = intrinsic.CMPLX((*(X)), imag((*(A))))`,
+		`(*(X)) = intrinsic.CABS((*(A)))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+	vetFiles(t, []string{out})
+}
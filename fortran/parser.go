@@ -173,9 +173,14 @@ type parser struct {
 	implicit []implicitVariable
 
 	functionExternalName []string
+	functionTypes        map[string]goType // types of FUNCTION results
 
 	initVars varInits // map of name to type
 
+	arguments map[string]goType // types of arguments
+
+	intents map[string]string // INTENT of arguments: IN, OUT, INOUT
+
 	comments []string
 
 	pkgs        map[string]bool // import packages
@@ -187,6 +192,8 @@ type parser struct {
 
 	formats map[string][]node // source line with command FORMAT
 
+	namelists map[string][]node // variables of NAMELIST groups
+
 	constants map[string][]node
 
 	errs []error
@@ -198,12 +205,16 @@ func (p *parser) addImport(pkg string) {
 
 func (p *parser) init() {
 	p.functionExternalName = make([]string, 0)
+	p.functionTypes = map[string]goType{}
+	p.intents = map[string]string{}
 	p.endLabelDo = map[string]int{}
 	p.allLabels = map[string]bool{}
 	p.foundLabels = map[string]bool{}
 	p.initVars = varInits{}
+	p.arguments = map[string]goType{}
 	p.parameters = map[string]string{}
 	p.formats = map[string][]node{}
+	p.namelists = map[string][]node{}
 	p.implicit = nil
 	p.constants = map[string][]node{}
 }
@@ -564,10 +575,33 @@ func (v vis) Visit(node goast.Node) (w goast.Visitor) {
 // delete external function type definition
 func (p *parser) removeExternalFunction() {
 	for _, f := range p.functionExternalName {
+		if v, ok := p.initVars.get(f); ok {
+			p.functionTypes[v.name] = v.typ
+		}
 		p.initVars.del(f)
 	}
 }
 
+// getFunctionType return type of FUNCTION result.
+// If function is not declared, then implicit rules are used:
+// names started from I-N are INTEGER, other are REAL.
+func (p *parser) getFunctionType(name string) goType {
+	name = strings.ToUpper(name)
+	if typ, ok := p.functionTypes[name]; ok {
+		return typ
+	}
+	if v, ok := p.initVars.get(name); ok {
+		return v.typ
+	}
+	if typ, ok := p.isImplicit(name[0]); ok {
+		return parseType(typ)
+	}
+	if 'I' <= name[0] && name[0] <= 'N' {
+		return goType{baseType: "int"}
+	}
+	return goType{baseType: "float64", single: true}
+}
+
 // add correct type of subroutine arguments
 func (p *parser) argumentCorrection(fd goast.FuncDecl) (removedVars []string) {
 checkArguments:
@@ -575,9 +609,13 @@ checkArguments:
 		fieldName := fd.Type.Params.List[i].Names[0].Name
 		if v, ok := p.initVars.get(fieldName); ok {
 			fd.Type.Params.List[i].Type = goast.NewIdent(v.typ.String())
+			if v.typ.isCharacter() {
+				p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+			}
 
 			// Remove to arg
 			removedVars = append(removedVars, fieldName)
+			p.arguments[fieldName] = v.typ
 			p.initVars.del(fieldName)
 			goto checkArguments
 		}
@@ -610,10 +648,14 @@ func (p *parser) initializeVars() (vars []goast.Stmt) {
 	}()
 	for i := range []varInitialization(p.initVars) {
 		name := ([]varInitialization(p.initVars)[i]).name
-		assign := strings.Contains(name, "COMMON.") || strings.Contains(name, returnPostfix)
+		assign := strings.Contains(name, "COMMON.")
 		goT := ([]varInitialization(p.initVars)[i]).typ
-		switch p.getArrayLen(name) {
-		case 0:
+		start := len(vars)
+		switch arrayLen := p.getArrayLen(name); {
+		case goT.isCharacter():
+			vars = append(vars, p.initializeCharacter(name, goT, assign)...)
+
+		case arrayLen == 0:
 
 			fset := token.NewFileSet() // positions are relative to fset
 			src := `package main
@@ -643,7 +685,7 @@ func main() {
 
 			vars = append(vars, list...)
 
-		case 1: // vector
+		case arrayLen == 1: // vector
 
 			fset := token.NewFileSet() // positions are relative to fset
 			src := `package main
@@ -674,7 +716,7 @@ func main() {
 
 			vars = append(vars, list...)
 
-		case 2: // matrix
+		case arrayLen == 2: // matrix
 
 			fset := token.NewFileSet() // positions are relative to fset
 			src := `package main
@@ -712,7 +754,7 @@ func main() {
 
 			vars = append(vars, list...)
 
-		case 3: // ()()()
+		case arrayLen == 3: // ()()()
 			fset := token.NewFileSet() // positions are relative to fset
 			src := `package main
 func main() {
@@ -754,7 +796,7 @@ func main() {
 
 			vars = append(vars, list...)
 
-		case 4: // ()()()()
+		case arrayLen == 4: // ()()()()
 			fset := token.NewFileSet() // positions are relative to fset
 			src := `package main
 func main() {
@@ -802,7 +844,7 @@ func main() {
 
 			vars = append(vars, list...)
 
-		case 5: // ()()()()()
+		case arrayLen == 5: // ()()()()()
 			fset := token.NewFileSet() // positions are relative to fset
 			src := `package main
 func main() {
@@ -859,6 +901,22 @@ func main() {
 			panic(fmt.Errorf(
 				"not correct amount of array : %v", goT))
 		}
+		if assign {
+			// COMMON block is allocated only once and shared
+			// between all routines:
+			//
+			//	if COMMON.BLOCK.X == nil {
+			//		COMMON.BLOCK.X = new(int)
+			//	}
+			vars = append(vars[:start], &goast.IfStmt{
+				Cond: &goast.BinaryExpr{
+					X:  goast.NewIdent(name),
+					Op: token.EQL,
+					Y:  goast.NewIdent("nil"),
+				},
+				Body: &goast.BlockStmt{List: append([]goast.Stmt{}, vars[start:]...)},
+			})
+		}
 	}
 
 	return
@@ -881,9 +939,6 @@ func isIgnoreCall(call *goast.CallExpr) bool {
 
 			case
 				"intrinsic":
-				if sel.Sel.Name == "READ" {
-					return false
-				}
 				return true
 			}
 		}
@@ -897,9 +952,21 @@ func isIgnoreCall(call *goast.CallExpr) bool {
 		}
 	}
 
+	if isSetCall(call) {
+		return true
+	}
+
 	return false
 }
 
+// isSetCall return true for assignment of CHARACTER value:
+//
+//	(*S).Set(...)
+func isSetCall(call *goast.CallExpr) bool {
+	sel, ok := call.Fun.(*goast.SelectorExpr)
+	return ok && sel.Sel.Name == "Set"
+}
+
 // Example
 //  From :
 // ab_min(3, 14)
@@ -922,13 +989,10 @@ func (c callArg) Visit(node goast.Node) goast.Visitor {
 		case *goast.BasicLit:
 			switch a.Kind {
 			case token.STRING:
+				c.p.addImport("github.com/Konstantin8105/f4go/intrinsic")
 				call.Args[i] = goast.NewIdent(
-					fmt.Sprintf("func()*[]byte{y:=[]byte(%s);return &y}()", a.Value))
-				if len(a.Value) == 3 {
-					a.Value = strings.Replace(a.Value, "\"", "'", -1)
-					call.Args[i] = goast.NewIdent(
-						fmt.Sprintf("func()*byte{y:=byte(%s);return &y}()", a.Value))
-				}
+					fmt.Sprintf("func()*%s{y:=%s(%s);return &y}()",
+						character, character, a.Value))
 			case token.INT:
 				call.Args[i] = goast.NewIdent(
 					fmt.Sprintf("func()*int{y:=%s;return &y}()", a.Value))
@@ -954,6 +1018,34 @@ func (c callArg) Visit(node goast.Node) goast.Visitor {
 				},
 			}
 
+		case *goast.CallExpr:
+			// from:  FUNC(...)
+			// to  :  func() *int { y := FUNC(...); return &y }()
+			if c.p.typeOf(a) == "[]byte" {
+				// from:  intrinsic.CONCAT(...)
+				// to  :  func() *intrinsic.Character { y := intrinsic.CONCAT(...); return &y }()
+				call.Args[i] = pointerOf(a, character)
+				break
+			}
+			if sel, ok := a.Fun.(*goast.SelectorExpr); ok && sel.Sel.Name == "ARRAY" && len(a.Args) > 0 {
+				// from:  intrinsic.ARRAY(1, 2)
+				// to  :  func() *[]int { y := intrinsic.ARRAY(1, 2); return &y }()
+				call.Args[i] = pointerOf(a, "[]"+c.p.typeOf(a.Args[0]))
+				break
+			}
+			id, ok := a.Fun.(*goast.Ident)
+			if !ok || isIgnoreCall(a) || !isUserFunction(id.Name) {
+				break
+			}
+			typ := c.p.getFunctionType(id.Name)
+			call.Args[i] = pointerOf(a, typ.sliceString())
+
+		case *goast.SliceExpr:
+			// substring is changed by reference
+			// from:  (*S)[1:4]
+			// to  :  func() *intrinsic.Character { y := (*S)[1:4]; return &y }()
+			call.Args[i] = pointerOf(a, character)
+
 		default:
 			// TODO:
 			// goast.Print(token.NewFileSet(), a)
@@ -964,6 +1056,46 @@ func (c callArg) Visit(node goast.Node) goast.Visitor {
 	return c
 }
 
+// pointerOf return pointer to value of expression with type typ:
+//
+//	func() *typ { y := e; return &y }()
+func pointerOf(e goast.Expr, typ string) goast.Expr {
+	return &goast.CallExpr{
+		Fun: &goast.FuncLit{
+			Type: &goast.FuncType{
+				Params: &goast.FieldList{},
+				Results: &goast.FieldList{List: []*goast.Field{{
+					Type: goast.NewIdent("*" + typ),
+				}}},
+			},
+			Body: &goast.BlockStmt{List: []goast.Stmt{
+				&goast.AssignStmt{
+					Lhs: []goast.Expr{goast.NewIdent("y")},
+					Tok: token.DEFINE,
+					Rhs: []goast.Expr{e},
+				},
+				&goast.ReturnStmt{Results: []goast.Expr{
+					&goast.UnaryExpr{Op: token.AND, X: goast.NewIdent("y")},
+				}},
+			}},
+		},
+	}
+}
+
+// isUserFunction return true for names of FUNCTION from Fortran source,
+// but not for intrinsic and builtin Go functions
+func isUserFunction(name string) bool {
+	if _, ok := intrinsicFunction[strings.ToUpper(name)]; ok {
+		return false
+	}
+	switch name {
+	case "make", "append", "panic", "new", "len", "cap",
+		"real", "imag", "complex":
+		return false
+	}
+	return !strings.Contains(name, ".")
+}
+
 // Example :
 //  COMPLEX FUNCTION CDOTU ( N , CX , INCX , CY , INCY )
 //  DOUBLE PRECISION FUNCTION DNRM2 ( N , X , INCX )
@@ -1038,14 +1170,22 @@ func (p *parser) parseSubroutine() (decl goast.Decl) {
 	Debugf("subroutine name is : %s", name)
 
 	// Add return type is exist
+	//
+	// Function is return value, but inside function body
+	// the result is local variable with pointer type:
+	//
+	//	func FUNC() int {
+	//		FUNC_RETURN := new(int)
+	//		...
+	//		return (*FUNC_RETURN)
+	//	}
 	returnName := name + returnPostfix
 	if len(returnType) > 0 {
 		typ := parseType(returnType)
 		fd.Type.Results = &goast.FieldList{
 			List: []*goast.Field{
 				{
-					Names: []*goast.Ident{goast.NewIdent(returnName)},
-					Type:  goast.NewIdent("*" + typ.String()),
+					Type: goast.NewIdent(typ.sliceString()),
 				},
 			},
 		}
@@ -1057,6 +1197,8 @@ func (p *parser) parseSubroutine() (decl goast.Decl) {
 			v := initVis()
 			v.c[name] = returnName
 			goast.Walk(v, fd.Body)
+
+			addReturnValue(fd.Body, returnName)
 		}
 	}()
 
@@ -1144,6 +1286,16 @@ func (p *parser) parseSubroutine() (decl goast.Decl) {
 	// init vars
 	fd.Body.List = append(p.initializeVars(), fd.Body.List...)
 
+	// add INTENT of arguments as comments
+	for i := len(fd.Type.Params.List) - 1; i >= 0; i-- {
+		fieldName := fd.Type.Params.List[i].Names[0].Name
+		if intent, ok := p.intents[fieldName]; ok {
+			fd.Body.List = append([]goast.Stmt{&goast.ExprStmt{
+				X: goast.NewIdent(intentComment(intent, fieldName)),
+			}}, fd.Body.List...)
+		}
+	}
+
 	// remove unused labels
 	removedLabels := map[string]bool{}
 	for k := range p.allLabels {
@@ -1247,12 +1399,76 @@ func (p *parser) parseListStmt() (stmts []goast.Stmt) {
 func (p *parser) parseInit() (stmts []goast.Stmt) {
 
 	// parse base type
+	// Example:
+	//  CHARACTER * ( N ) NAME
+	//  CHARACTER * LENGTH NAME
 	var baseType []node
-	for ; p.ns[p.ident].tok != token.IDENT; p.ident++ {
+	for counter := 0; p.ns[p.ident].tok != token.IDENT || counter > 0 ||
+		p.ns[p.ident-1].tok == token.MUL; p.ident++ {
+		switch p.ns[p.ident].tok {
+		case token.LPAREN:
+			counter++
+		case token.RPAREN:
+			counter--
+		}
 		baseType = append(baseType, p.ns[p.ident])
 	}
 	p.expect(token.IDENT)
 
+	// parse attributes
+	// Example:
+	//  INTEGER, INTENT(IN), DIMENSION(3) :: A
+	var intent string
+	var dimension []node
+	if last := len(baseType) - 1; last >= 0 && baseType[last].tok == token.COMMA {
+		baseType = baseType[:last]
+		for ; p.ns[p.ident].tok == token.IDENT; p.ident++ {
+			attr := strings.ToUpper(string(p.ns[p.ident].b))
+			var value []node
+			if p.ns[p.ident+1].tok == token.LPAREN {
+				counter := 0
+				for p.ident++; ; p.ident++ {
+					switch p.ns[p.ident].tok {
+					case token.LPAREN:
+						counter++
+					case token.RPAREN:
+						counter--
+					case ftNewLine:
+						p.addError("Cannot parse attribute : not expected NEW_LINE")
+						return
+					}
+					value = append(value, p.ns[p.ident])
+					if counter == 0 {
+						break
+					}
+				}
+			}
+			switch attr {
+			case "INTENT":
+				// INTENT ( IN OUT )
+				for _, n := range value[1 : len(value)-1] {
+					intent += strings.ToUpper(string(n.b))
+				}
+			case "DIMENSION":
+				dimension = value
+			}
+			if p.ns[p.ident+1].tok != token.COMMA {
+				break
+			}
+			p.ident++
+		}
+		p.ident++
+		p.expect(ftDoubleColon)
+		p.ident++
+		p.expect(token.IDENT)
+	}
+
+	if len(baseType) > 1 && baseType[0].tok == ftCharacter && baseType[1].tok == token.LPAREN {
+		// from : CHARACTER ( 8 )
+		// to   : CHARACTER * ( 8 )
+		baseType = append([]node{baseType[0], {tok: token.MUL, b: []byte("*")}}, baseType[1:]...)
+	}
+
 	var name string
 	var additionType []node
 	for ; p.ns[p.ident].tok != ftNewLine &&
@@ -1289,7 +1505,13 @@ func (p *parser) parseInit() (stmts []goast.Stmt) {
 		}
 
 		// parse type = base type + addition type
+		if len(additionType) == 0 {
+			additionType = dimension
+		}
 		p.initVars.add(name, parseType(append(baseType, additionType...)))
+		if intent != "" {
+			p.intents[strings.ToUpper(name)] = intent
+		}
 		if p.ns[p.ident].tok != token.COMMA {
 			p.ident--
 		}
@@ -1509,7 +1731,7 @@ func (p *parser) parseDo() (sDo goast.ForStmt) {
 			break
 		}
 	}
-	sDo.Cond = p.parseBinary(start, p.ident)
+	sDo.Cond = p.parseExpr(start, p.ident)
 
 	if p.ns[p.ident].tok == ftNewLine {
 		sDo.Post = &goast.IncDecStmt{
@@ -1544,19 +1766,6 @@ func (p *parser) parseDo() (sDo goast.ForStmt) {
 	return
 }
 
-func (p *parser) parseBinary(start, finish int) (expr goast.Expr) {
-	expr = p.parseExpr(start, finish)
-	if b, ok := expr.(*goast.BinaryExpr); ok {
-		if _, ok := b.X.(*goast.CallExpr); ok {
-			b.X = &goast.ParenExpr{X: &goast.StarExpr{X: b.X}}
-		}
-		if _, ok := b.Y.(*goast.CallExpr); ok {
-			b.Y = &goast.ParenExpr{X: &goast.StarExpr{X: b.Y}}
-		}
-	}
-	return
-}
-
 func (p *parser) parseIf() (sIf goast.IfStmt) {
 	p.ident++
 	p.expect(token.LPAREN)
@@ -1579,7 +1788,7 @@ func (p *parser) parseIf() (sIf goast.IfStmt) {
 		}
 	}
 
-	sIf.Cond = p.parseBinary(start, p.ident)
+	sIf.Cond = p.parseExpr(start, p.ident)
 
 	p.expect(token.RPAREN)
 	p.ident++
@@ -1684,8 +1893,12 @@ func (p *parser) parseStmt() (stmts []goast.Stmt) {
 		p.addError(p.getLine())
 		p.gotoEndLine()
 
-	case ftRewind:
-		s := p.parseRewind()
+	case ftRewind, ftBackspace, ftEndfile:
+		s := p.parsePosition()
+		stmts = append(stmts, s...)
+
+	case ftInquire:
+		s := p.parseInquire()
 		stmts = append(stmts, s...)
 
 	case ftDimension:
@@ -1718,8 +1931,9 @@ func (p *parser) parseStmt() (stmts []goast.Stmt) {
 		}
 
 	case ftFormat:
+		// FORMAT is used by WRITE, READ statements
 		stmts = append(stmts, &goast.ExprStmt{
-			X: goast.NewIdent("// Unused by f4go : " + p.getLine()),
+			X: goast.NewIdent("//" + p.getLine()),
 		})
 		p.gotoEndLine()
 
@@ -1727,6 +1941,13 @@ func (p *parser) parseStmt() (stmts []goast.Stmt) {
 		s := p.parseCommon()
 		stmts = append(stmts, s...)
 
+	case ftNamelist:
+		// NAMELIST is used by WRITE, READ statements
+		stmts = append(stmts, &goast.ExprStmt{
+			X: goast.NewIdent("//" + p.getLine()),
+		})
+		p.parseNamelist()
+
 	case token.RETURN:
 		stmts = append(stmts, &goast.ReturnStmt{})
 		p.gotoEndLine()
@@ -1798,41 +2019,16 @@ func (p *parser) parseStmt() (stmts []goast.Stmt) {
 		stmts = append(stmts, sData...)
 
 	case ftWrite:
-		stmts = append(stmts,
-			&goast.ExprStmt{
-				X: goast.NewIdent("// Unused by f4go : " + p.getLine()),
-			},
-			&goast.ExprStmt{
-				goast.NewIdent("fmt.Println(\"WRITE SOMETHING\")"),
-			},
-		)
-		p.gotoEndLine()
+		s := p.parseWrite()
+		stmts = append(stmts, s...)
 
 	case ftPrint:
-		stmts = append(stmts, &goast.ExprStmt{
-			X: goast.NewIdent("// Unused by f4go : " + p.getLine()),
-		})
-		p.gotoEndLine()
+		s := p.parsePrint()
+		stmts = append(stmts, s...)
 
-	case ftStop:
-		p.expect(ftStop)
-		p.ident++
-		var msg []byte
-		for ; p.ident < len(p.ns) && p.ns[p.ident].tok != ftNewLine; p.ident++ {
-			msg = append(msg, p.ns[p.ident].b...)
-		}
-		stmts = append(stmts, &goast.ExprStmt{
-			X: &goast.CallExpr{
-				Fun:    goast.NewIdent("panic"),
-				Lparen: 1,
-				Args: []goast.Expr{
-					&goast.BasicLit{
-						Kind:  token.STRING,
-						Value: "\"" + string(msg) + "\"",
-					},
-				},
-			},
-		})
+	case ftStop, ftErrorStop:
+		sStop := p.parseStop()
+		stmts = append(stmts, sStop)
 
 	case token.GOTO:
 		// Examples:
@@ -1957,7 +2153,9 @@ func (p *parser) parseStmt() (stmts []goast.Stmt) {
 		pos := start
 		if p.ns[start].tok == token.IDENT {
 			pos++
-			if p.ns[pos].tok == token.LPAREN {
+			// A(I) = ...
+			// A(I)(J:K) = ...
+			for p.ns[pos].tok == token.LPAREN {
 				counter := 0
 				for ; pos < len(p.ns); pos++ {
 					switch p.ns[pos].tok {
@@ -2008,18 +2206,25 @@ func (p *parser) parseStmt() (stmts []goast.Stmt) {
 				}
 			}
 
-			// add assign
-			assign := goast.AssignStmt{
-				Lhs: []goast.Expr{p.parseExpr(start, pos)},
-				Tok: token.ASSIGN, // =
-				Rhs: []goast.Expr{p.parseExpr(pos+1, p.ident)},
-			}
-			if f, ok := assign.Rhs[0].(*goast.CallExpr); ok {
-				if !isIgnoreCall(f) {
-					assign.Rhs[0] = &goast.ParenExpr{X: &goast.StarExpr{X: assign.Rhs[0]}}
+			if p.isCharacterVariable(string(p.ns[start].b)) {
+				// value of CHARACTER is copied with blank padding:
+				//  S = 'ABC'  - (*S).Set([]byte("ABC"))
+				stmts = append(stmts, &goast.ExprStmt{X: &goast.CallExpr{
+					Fun: &goast.SelectorExpr{
+						X:   p.parseExpr(start, pos),
+						Sel: goast.NewIdent("Set"),
+					},
+					Args: []goast.Expr{p.parseExpr(pos+1, p.ident)},
+				}})
+			} else {
+				// add assign
+				assign := goast.AssignStmt{
+					Lhs: []goast.Expr{p.parseExpr(start, pos)},
+					Tok: token.ASSIGN, // =
+					Rhs: []goast.Expr{p.parseExpr(pos+1, p.ident)},
 				}
+				stmts = append(stmts, &assign)
 			}
-			stmts = append(stmts, &assign)
 		} else {
 			nodes := p.parseExpr(start, p.ident)
 			stmts = append(stmts, &goast.ExprStmt{
@@ -2277,8 +2482,8 @@ func (p *parser) parseData() (stmts []goast.Stmt) {
 	// (LL( J ), J = 1, 4 )     - one row of vector
 	// (LL( 1, J ), J = 1, 4 )  - one row of matrix
 	type tExpr struct {
-		expr   goast.Expr
-		isByte bool
+		expr        goast.Expr
+		isCharacter bool
 	}
 
 	for _, name := range names {
@@ -2399,11 +2604,10 @@ namesExplode:
 	var nameExpr []tExpr
 	for _, name := range names {
 		v, _ := p.initVars.get(nodesToString(name[:1]))
-		isByte := v.typ.getBaseType() == "byte"
 		n := p.parseExprNodes(name)
 		nameExpr = append(nameExpr, tExpr{
-			expr:   n,
-			isByte: isByte,
+			expr:        n,
+			isCharacter: v.typ.isCharacter(),
 		})
 	}
 
@@ -2436,32 +2640,6 @@ mul:
 		goto mul
 	}
 
-	for k := range values {
-		for j := range values[k] {
-			// [[STRING, `"123456"`, {898 24}]]
-			if values[k][j].tok != token.STRING {
-				continue
-			}
-			if len(values[k][j].b) < 4 {
-				continue
-			}
-
-			var inject [][]node
-			for r := range values[k][j].b {
-				if r == 0 || r == len(values[k][j].b)-1 {
-					continue
-				}
-				inject = append(inject, []node{{
-					tok: token.STRING,
-					b:   []byte{'\'', values[k][j].b[r], '\''},
-				}})
-			}
-			values = append(values[:k], append(inject, values[k+1:]...)...)
-
-			goto mul
-		}
-	}
-
 	if len(nameExpr) != len(values) {
 		var str string
 		for i := range names {
@@ -2504,19 +2682,25 @@ mul:
 		assign.Tok = token.ASSIGN // =
 
 		for i := range nameExpr {
-			if nameExpr[i].isByte {
-				e := p.parseExprNodes(values[i])
-				e.(*goast.BasicLit).Kind = token.CHAR
-				e.(*goast.BasicLit).Value = fmt.Sprintf("'%c'", e.(*goast.BasicLit).Value[1])
-				assign.Lhs = append(assign.Lhs, nameExpr[i].expr)
-				assign.Rhs = append(assign.Rhs, e)
+			if nameExpr[i].isCharacter {
+				// DATA S / 'ABC' /
+				// (*S).Set([]byte("ABC"))
+				stmts = append(stmts, &goast.ExprStmt{X: &goast.CallExpr{
+					Fun: &goast.SelectorExpr{
+						X:   nameExpr[i].expr,
+						Sel: goast.NewIdent("Set"),
+					},
+					Args: []goast.Expr{p.parseExprNodes(values[i])},
+				}})
 				continue
 			}
 			assign.Lhs = append(assign.Lhs, nameExpr[i].expr)
 			assign.Rhs = append(assign.Rhs, p.parseExprNodes(values[i]))
 		}
 
-		stmts = append(stmts, &assign)
+		if len(assign.Lhs) > 0 {
+			stmts = append(stmts, &assign)
+		}
 	}
 
 	return
@@ -2825,18 +3009,8 @@ func (p *parser) parseCommon() (stmts []goast.Stmt) {
 			inject = append(inject, node{tok: ftNewLine, b: []byte("\n")})
 		}
 
-		// INTEGER COMMON.blockName.name
-		n = scan([]byte(names[i]))
-		inject = append(inject, node{tok: ftNewLine, b: []byte("\n")})
-		if i == 0 {
-			inject = append(inject, node{tok: ftInteger, b: []byte("INTEGER")})
-		} else {
-			inject = append(inject, node{tok: ftReal, b: []byte("REAL")})
-		}
-		n[0].b = append([]byte("COMMON."+blockName+"."), n[0].b...)
-		n[0].tok = token.IDENT
-		inject = append(inject, n...)
-		inject = append(inject, node{tok: ftNewLine, b: []byte("\n")})
+		// COMMON.blockName.name with type of variable
+		p.initVars.add("COMMON."+blockName+"."+name, variables[i].typ)
 
 		p.ns = append(p.ns[:p.ident], append(inject, p.ns[p.ident:]...)...)
 
@@ -0,0 +1,50 @@
+package fortran
+
+import (
+	"bytes"
+	"go/format"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestCommonAllocation(t *testing.T) {
+	// values of COMMON block are kept between calls of routines
+	ast, errs := Parse([]byte(`
+      PROGRAM MAIN
+      INTEGER N
+      DOUBLE PRECISION X
+      COMMON /BLK/ N, X
+      N = 5
+      CALL ADD1
+      END
+
+      SUBROUTINE ADD1
+      INTEGER N
+      DOUBLE PRECISION X
+      COMMON /BLK/ N, X
+      N = N + 1
+      END
+`), "main")
+	if len(errs) > 0 {
+		t.Fatalf("%v", errs)
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), &ast); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		`if COMMON.BLK.N == nil {
+		COMMON.BLK.N = new(int)
+	}`,
+		`if COMMON.BLK.X == nil {
+		COMMON.BLK.X = new(float64)
+	}`,
+		`N = COMMON.BLK.N`,
+	} {
+		if c := strings.Count(out, s); c != 2 {
+			t.Errorf("Found %d times `%s` in:\n%s", c, s, out)
+		}
+	}
+}
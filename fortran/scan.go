@@ -160,6 +160,10 @@ func scan(b []byte) (ns []node) {
 	Debugf("Scan: token GOTO")
 	s.scanGoto()
 
+	// token ERROR STOP
+	Debugf("Scan: token ERROR STOP")
+	s.scanErrorStop()
+
 	// ::
 	Debugf("Scan: token DOUBLE_COLON ::")
 	s.scanDoubleColon()
@@ -564,6 +568,23 @@ func (s *scanner) postprocessor() {
 		// TODO
 	}
 
+	// From:
+	//  END FILE 10
+	// To:
+	//  ENDFILE 10
+	for e := s.nodes.Front(); e != nil; e = e.Next() {
+		if e.Value.(*node).tok != ftEnd {
+			continue
+		}
+		n := e.Next()
+		if n == nil || n.Value.(*node).tok != token.IDENT ||
+			strings.ToUpper(string(n.Value.(*node).b)) != "FILE" {
+			continue
+		}
+		e.Value.(*node).tok, e.Value.(*node).b = ftEndfile, []byte("ENDFILE")
+		s.nodes.Remove(n)
+	}
+
 	// From:
 	//  END SUBROUTINE
 	//  END IF
@@ -981,6 +1002,10 @@ func (s *scanner) scanTokens() {
 		{tok: ftEquivalence, pattern: []string{"EQUIVALENCE"}},
 		{tok: ftCommon, pattern: []string{"COMMON"}},
 		{tok: ftRewind, pattern: []string{"REWIND"}},
+		{tok: ftBackspace, pattern: []string{"BACKSPACE"}},
+		{tok: ftEndfile, pattern: []string{"ENDFILE"}},
+		{tok: ftInquire, pattern: []string{"INQUIRE"}},
+		{tok: ftNamelist, pattern: []string{"NAMELIST"}},
 		{tok: ftInclude, pattern: []string{"INCLUDE"}},
 	}
 	for _, ent := range entities {
@@ -1313,6 +1338,22 @@ G:
 	}
 }
 
+func (s *scanner) scanErrorStop() {
+	for e := s.nodes.Front(); e != nil; e = e.Next() {
+		if !(e.Value.(*node).tok == token.IDENT &&
+			strings.ToUpper(string(e.Value.(*node).b)) == "ERROR") {
+			continue
+		}
+		n := e.Next()
+		if n == nil || n.Value.(*node).tok != ftStop {
+			continue
+		}
+		e.Value.(*node).tok = ftErrorStop
+		e.Value.(*node).b = []byte("ERROR STOP")
+		s.nodes.Remove(n)
+	}
+}
+
 func isSpace(ch byte) bool { return ch == ' ' || ch == '\t' || ch == '\r' }
 
 // isLetter returns true if the rune is a letter.
@@ -0,0 +1,559 @@
+package fortran
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/format"
+	goparser "go/parser"
+	goscanner "go/scanner"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// Simplify changes arguments of functions from pointers to values, if it
+// is possible. All files of one package must be simplified together,
+// because signatures of functions and all calls of them are changed.
+//
+// Rules:
+//   - scalar argument, which is never assigned inside the function
+//     (directly, by the call of other function or by the address),
+//     is passed by value;
+//   - array argument is passed as slice;
+//   - all other arguments are not changed.
+//
+// Example, from:
+//
+//	func SUM(N *int, A *[]float64) float64 { ... (*(N)) ... (*(A))[i] ... }
+//	...
+//	SUM(func() *int { y := 3; return &y }(), A)
+//
+// to:
+//
+//	func SUM(N int, A []float64) float64 { ... N ... A[i] ... }
+//	...
+//	SUM(3, (*A))
+func Simplify(files []*goast.File) {
+	s := simplifier{
+		funcs: map[string]*goast.FuncDecl{},
+	}
+	for _, f := range files {
+		expandCode(f)
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*goast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Body == nil {
+				continue
+			}
+			s.funcs[fd.Name.Name] = fd
+		}
+	}
+
+	s.findArguments(files)
+	s.analyze()
+
+	// change arguments in calls of functions
+	for _, f := range files {
+		rewriteExpr(f, s.changeCall)
+	}
+
+	defer func() {
+		for _, f := range files {
+			collapseCode(f)
+		}
+	}()
+
+	// change functions
+	for name, args := range s.args {
+		fd := s.funcs[name]
+		values := map[string]bool{}
+		for _, a := range args {
+			if !a.value {
+				continue
+			}
+			field := fd.Type.Params.List[a.pos]
+			field.Type = field.Type.(*goast.StarExpr).X
+			values[a.name] = true
+		}
+		if len(values) == 0 {
+			continue
+		}
+		rewriteExpr(fd.Body, func(e goast.Expr) goast.Expr {
+			// from: (*(A))
+			// to  : A
+			if id, ok := removeParen(e).(*goast.Ident); ok && values[id.Name] {
+				return id
+			}
+			if st, ok := removeParen(e).(*goast.StarExpr); ok {
+				if id, ok := removeParen(st.X).(*goast.Ident); ok && values[id.Name] {
+					return id
+				}
+			}
+			return e
+		})
+	}
+}
+
+// argument of function
+type argument struct {
+	name  string
+	pos   int    // position in list of function parameters
+	typ   string // type of argument, for example: "*int", "*[][]float64"
+	value bool   // argument can be passed by value
+
+	// list of arguments in other functions, which are used
+	// the argument by pointer
+	links []*argument
+}
+
+func (a argument) isArray() bool {
+	return strings.HasPrefix(a.typ, "*[")
+}
+
+type simplifier struct {
+	funcs map[string]*goast.FuncDecl
+	args  map[string][]*argument
+}
+
+// findArguments found all pointer arguments of functions and
+// check all cases of usage that arguments
+func (s *simplifier) findArguments(files []*goast.File) {
+	s.args = map[string][]*argument{}
+
+	// functions used not in calls, for example as
+	// arguments of other functions, cannot be changed
+	escaped := map[string]bool{}
+	for _, f := range files {
+		walkWithParent(f, func(n, parent goast.Node) {
+			switch n := n.(type) {
+			case *goast.Ident:
+				if _, ok := s.funcs[n.Name]; ok {
+					if call, ok := parent.(*goast.CallExpr); ok && call.Fun == n {
+						return
+					}
+					if fd, ok := parent.(*goast.FuncDecl); ok && fd.Name == n {
+						return
+					}
+					escaped[n.Name] = true
+					return
+				}
+				for _, name := range codeIdents(n.Name) {
+					escaped[name] = true
+				}
+			case *goast.BasicLit:
+				for _, name := range codeIdents(n.Value) {
+					escaped[name] = true
+				}
+			}
+		})
+	}
+
+	for name, fd := range s.funcs {
+		if escaped[name] {
+			continue
+		}
+		var args []*argument
+		for i, field := range fd.Type.Params.List {
+			if len(field.Names) != 1 {
+				args = append(args, &argument{pos: i})
+				continue
+			}
+			if _, ok := field.Type.(*goast.StarExpr); !ok {
+				args = append(args, &argument{pos: i})
+				continue
+			}
+			args = append(args, &argument{
+				name:  field.Names[0].Name,
+				pos:   i,
+				typ:   types.ExprString(field.Type),
+				value: true,
+			})
+		}
+		s.args[name] = args
+	}
+
+	for name, args := range s.args {
+		for _, a := range args {
+			if a.value {
+				s.checkUsage(s.funcs[name].Body, a)
+			}
+		}
+	}
+}
+
+// checkUsage check all usage of argument inside body of function
+func (s *simplifier) checkUsage(body *goast.BlockStmt, a *argument) {
+	var parents []goast.Node
+	goast.Inspect(body, func(n goast.Node) bool {
+		if n == nil {
+			parents = parents[:len(parents)-1]
+			return true
+		}
+		defer func() {
+			parents = append(parents, n)
+		}()
+
+		switch n := n.(type) {
+		case *goast.BasicLit:
+			for _, name := range codeIdents(n.Value) {
+				if name == a.name {
+					a.value = false
+				}
+			}
+			return true
+		case *goast.Ident:
+			if n.Name != a.name {
+				for _, name := range codeIdents(n.Name) {
+					if name == a.name {
+						a.value = false
+					}
+				}
+				return true
+			}
+		default:
+			return true
+		}
+
+		// ignore parens
+		// from: ((A))
+		// to  : A
+		level := len(parents) - 1
+		var expr goast.Node = n
+		for level > 0 {
+			if _, ok := parents[level].(*goast.ParenExpr); !ok {
+				break
+			}
+			expr = parents[level]
+			level--
+		}
+		parent := parents[level]
+
+		if sel, ok := parent.(*goast.SelectorExpr); ok && sel.Sel == n {
+			// COMMON.A is not argument
+			return true
+		}
+
+		// argument in call of other function
+		if call, ok := parent.(*goast.CallExpr); ok {
+			for i := range call.Args {
+				if call.Args[i] != expr {
+					continue
+				}
+				id, ok := call.Fun.(*goast.Ident)
+				if !ok {
+					break
+				}
+				args, ok := s.args[id.Name]
+				if !ok || i >= len(args) || !args[i].value || args[i].typ != a.typ {
+					break
+				}
+				a.links = append(a.links, args[i])
+				return true
+			}
+			a.value = false
+			return true
+		}
+
+		// only value of argument is used
+		st, ok := parent.(*goast.StarExpr)
+		if !ok {
+			a.value = false
+			return true
+		}
+		level--
+		expr = st
+		for level > 0 {
+			if _, ok := parents[level].(*goast.ParenExpr); !ok {
+				break
+			}
+			expr = parents[level]
+			level--
+		}
+		switch p := parents[level].(type) {
+		case *goast.AssignStmt:
+			for _, lhs := range p.Lhs {
+				if lhs == expr {
+					a.value = false
+				}
+			}
+		case *goast.IncDecStmt:
+			a.value = false
+		case *goast.UnaryExpr:
+			if p.Op == token.AND {
+				a.value = false
+			}
+		case *goast.CallExpr:
+			if p.Fun == expr {
+				a.value = false
+			}
+		case *goast.IndexExpr:
+			if !a.isArray() {
+				a.value = false
+			}
+		}
+		return true
+	})
+}
+
+// analyze remove arguments, which are passed to arguments of other
+// functions by pointer and cannot be changed
+func (s *simplifier) analyze() {
+	for changed := true; changed; {
+		changed = false
+		for _, args := range s.args {
+			for _, a := range args {
+				if !a.value {
+					continue
+				}
+				for _, l := range a.links {
+					if !l.value {
+						a.value = false
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// changeCall change arguments of calls from pointer to value
+func (s *simplifier) changeCall(e goast.Expr) goast.Expr {
+	call, ok := e.(*goast.CallExpr)
+	if !ok {
+		return e
+	}
+	id, ok := call.Fun.(*goast.Ident)
+	if !ok {
+		return e
+	}
+	args, ok := s.args[id.Name]
+	if !ok {
+		return e
+	}
+	for i := range call.Args {
+		if i >= len(args) || !args[i].value {
+			continue
+		}
+		call.Args[i] = valueOf(call.Args[i])
+	}
+	return e
+}
+
+// valueOf return value of pointer expression.
+//
+//	&(A)                                -> A
+//	func() *int { y := 3; return &y }() -> 3
+//	A                                   -> (*A)
+func valueOf(e goast.Expr) goast.Expr {
+	switch v := removeParen(e).(type) {
+	case *goast.UnaryExpr:
+		if v.Op == token.AND {
+			return v.X
+		}
+	case *goast.CallExpr:
+		if value, ok := pointerValue(v); ok {
+			return value
+		}
+	case *goast.Ident, *goast.SelectorExpr:
+		return &goast.ParenExpr{X: &goast.StarExpr{X: v}}
+	}
+	// expression is not a pointer
+	return e
+}
+
+// pointerValue return value from pointer expression like:
+//
+//	func() *int { y := 3; return &y }()
+func pointerValue(call *goast.CallExpr) (value goast.Expr, ok bool) {
+	f, ok := call.Fun.(*goast.FuncLit)
+	if !ok || len(call.Args) != 0 || len(f.Body.List) != 2 {
+		return nil, false
+	}
+	assign, ok := f.Body.List[0].(*goast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, false
+	}
+	ret, ok := f.Body.List[1].(*goast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil, false
+	}
+	// return &y
+	addr, ok := ret.Results[0].(*goast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return nil, false
+	}
+	y, ok := addr.X.(*goast.Ident)
+	if !ok {
+		return nil, false
+	}
+	if lhs, ok := assign.Lhs[0].(*goast.Ident); !ok || lhs.Name != y.Name {
+		return nil, false
+	}
+	return assign.Rhs[0], true
+}
+
+// collapseCode is opposite of expandCode for pointer values, because
+// function literals without positions are printed on many lines
+// Example:
+//
+//	func() *int {
+//		y := 3
+//		return &y
+//	}()
+//
+// to:
+//
+//	func() *int { y := 3; return &y }()
+func collapseCode(node goast.Node) {
+	rewriteExpr(node, func(e goast.Expr) goast.Expr {
+		call, ok := e.(*goast.CallExpr)
+		if !ok {
+			return e
+		}
+		value, ok := pointerValue(call)
+		if !ok {
+			return e
+		}
+		f := call.Fun.(*goast.FuncLit)
+		if f.Type.Results == nil || len(f.Type.Results.List) != 1 {
+			return e
+		}
+		var typ, val bytes.Buffer
+		fset := token.NewFileSet()
+		if format.Node(&typ, fset, f.Type.Results.List[0].Type) != nil ||
+			format.Node(&val, fset, value) != nil {
+			return e
+		}
+		return goast.NewIdent(fmt.Sprintf("func() %s { y := %s; return &y }()",
+			typ.String(), val.String()))
+	})
+}
+
+// expandCode replace Go code inside Ident and BasicLit by go/ast nodes
+// Example:
+//
+//	Ident{Name: "(*A)"} -> ParenExpr{X: StarExpr{X: Ident{Name: "A"}}}
+func expandCode(node goast.Node) {
+	rewriteExpr(node, func(e goast.Expr) goast.Expr {
+		var code string
+		switch v := e.(type) {
+		case *goast.Ident:
+			if token.IsIdentifier(v.Name) {
+				return e
+			}
+			code = v.Name
+		case *goast.BasicLit:
+			code = v.Value
+		default:
+			return e
+		}
+		expr, err := goparser.ParseExpr(code)
+		if err != nil {
+			// comments or statements
+			return e
+		}
+		if _, ok := expr.(*goast.BasicLit); ok {
+			return e
+		}
+		return expr
+	})
+}
+
+func removeParen(e goast.Expr) goast.Expr {
+	for {
+		p, ok := e.(*goast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
+
+// codeIdents return names from Go code inside of Ident or BasicLit.
+// For simple names result is nil.
+func codeIdents(code string) (names []string) {
+	if token.IsIdentifier(code) {
+		return nil
+	}
+	var sc goscanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	sc.Init(file, []byte(code), nil, 0)
+	for {
+		_, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.IDENT {
+			names = append(names, lit)
+		}
+	}
+	return
+}
+
+// walkWithParent walk by go/ast tree and run function f for each node
+// with parent of that node
+func walkWithParent(node goast.Node, f func(n, parent goast.Node)) {
+	var parents []goast.Node
+	goast.Inspect(node, func(n goast.Node) bool {
+		if n == nil {
+			parents = parents[:len(parents)-1]
+			return true
+		}
+		var parent goast.Node
+		if len(parents) > 0 {
+			parent = parents[len(parents)-1]
+		}
+		f(n, parent)
+		parents = append(parents, n)
+		return true
+	})
+}
+
+var (
+	exprType   = reflect.TypeOf((*goast.Expr)(nil)).Elem()
+	objectType = reflect.TypeOf((*goast.Object)(nil))
+	scopeType  = reflect.TypeOf((*goast.Scope)(nil))
+)
+
+// rewriteExpr replace all expressions in node by result of function f.
+// Function f is called for children before parents.
+func rewriteExpr(node goast.Node, f func(goast.Expr) goast.Expr) {
+	rewriteValue(reflect.ValueOf(node), f)
+}
+
+func rewriteValue(v reflect.Value, f func(goast.Expr) goast.Expr) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type() == objectType || v.Type() == scopeType {
+			return
+		}
+		if v.Elem().Kind() != reflect.Struct {
+			return
+		}
+		v = v.Elem()
+		for i := 0; i < v.NumField(); i++ {
+			rewriteField(v.Field(i), f)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			rewriteValue(v.Elem(), f)
+		}
+	}
+}
+
+func rewriteField(v reflect.Value, f func(goast.Expr) goast.Expr) {
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			rewriteField(v.Index(i), f)
+		}
+	case reflect.Ptr, reflect.Interface:
+		rewriteValue(v, f)
+		if v.Type() == exprType && !v.IsNil() && v.CanSet() {
+			v.Set(reflect.ValueOf(f(v.Interface().(goast.Expr))))
+		}
+	}
+}
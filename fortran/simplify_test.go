@@ -0,0 +1,47 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE SETA(N, A, K)
+      INTEGER N, A(*), K
+      INTEGER I
+      DO I = 1, N
+        A(I) = I
+      END DO
+      K = N
+      END
+
+      SUBROUTINE WRAP(N, A, K)
+      INTEGER N, A(*), K
+      CALL SETA(N, A, K)
+      END
+
+      SUBROUTINE INCR(N)
+      INTEGER N
+      N = N + 1
+      END
+
+      SUBROUTINE WRAPINC(N)
+      INTEGER N
+      CALL INCR(N)
+      END
+`, Simplify)
+
+	for _, s := range []string{
+		"func SETA(N int, A []int, K *int)",
+		"func WRAP(N int, A []int, K *int)",
+		"func INCR(N *int)",
+		"func WRAPINC(N *int)",
+		"SETA(N, A, (K))",
+		"A[(*I)-(1)] = (*I)",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
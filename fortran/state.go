@@ -0,0 +1,333 @@
+package fortran
+
+import (
+	goast "go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	stateType   = "State"
+	memoryType  = "MEMORY"
+	commonName  = "COMMON"
+	unitsName   = "UNITS"
+	programName = "main"
+)
+
+// ioStatements is functions of package intrinsic, which are changed to
+// methods of table of units in reentrant code
+var ioStatements = map[string]bool{
+	"WRITE": true, "WRITEU": true, "READ": true, "READU": true,
+	"WRITENML": true, "READNML": true,
+	"OPEN": true, "CLOSE": true, "INQUIRE": true,
+	"REWIND": true, "BACKSPACE": true, "ENDFILE": true,
+}
+
+// Reentrant moves all global state of fortran program to struct State,
+// so different instances of program can run concurrently.
+//
+// Rules:
+//   - types MEMORY of COMMON blocks from all files are merged in the
+//     first file and used as field of struct State;
+//   - all functions are changed to methods of *State;
+//   - all calls and usage of COMMON are changed to receiver;
+//   - input/output statements use table of units of State, nil
+//     table is default table of package intrinsic;
+//   - PROGRAM creates new State.
+//
+// Example, from:
+//
+//	type MEMORY struct { ... }
+//	var COMMON MEMORY
+//	func F() {
+//		COMMON.PDAT.W = new(float64)
+//		G()
+//	}
+//
+// to:
+//
+//	type MEMORY struct { ... }
+//	type State struct {
+//		COMMON MEMORY
+//	}
+//	func (s *State) F() {
+//		s.COMMON.PDAT.W = new(float64)
+//		s.G()
+//	}
+func Reentrant(files []*goast.File) {
+	if len(files) == 0 {
+		return
+	}
+
+	funcs := map[string]bool{}
+	blocks := map[string]*goast.Field{}
+	var units bool
+	for _, f := range files {
+		expandCode(f)
+		var decls []goast.Decl
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*goast.FuncDecl); ok && fd.Recv == nil && fd.Name.Name != programName {
+				funcs[fd.Name.Name] = true
+			}
+			if gen, ok := decl.(*goast.GenDecl); ok && isMemory(gen) {
+				if gen.Tok == token.TYPE {
+					st := gen.Specs[0].(*goast.TypeSpec).Type.(*goast.StructType)
+					for _, field := range st.Fields.List {
+						if _, ok := blocks[field.Names[0].Name]; !ok {
+							blocks[field.Names[0].Name] = field
+						}
+					}
+				}
+				continue
+			}
+			decls = append(decls, decl)
+		}
+		f.Decls = decls
+	}
+	defer func() {
+		for _, f := range files {
+			collapseCode(f)
+		}
+	}()
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*goast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Body == nil {
+				continue
+			}
+			recv := receiverName(fd)
+			if fd.Name.Name == programName {
+				// s := new(State)
+				fd.Body.List = append([]goast.Stmt{&goast.AssignStmt{
+					Lhs: []goast.Expr{goast.NewIdent(recv)},
+					Tok: token.DEFINE,
+					Rhs: []goast.Expr{&goast.CallExpr{
+						Fun:  goast.NewIdent("new"),
+						Args: []goast.Expr{goast.NewIdent(stateType)},
+					}},
+				}}, fd.Body.List...)
+			} else {
+				fd.Recv = &goast.FieldList{List: []*goast.Field{{
+					Names: []*goast.Ident{goast.NewIdent(recv)},
+					Type:  &goast.StarExpr{X: goast.NewIdent(stateType)},
+				}}}
+			}
+
+			// parameters and local variables shadow functions
+			params := localNames(fd)
+			rewriteExpr(fd.Body, func(e goast.Expr) goast.Expr {
+				switch e := e.(type) {
+				case *goast.SelectorExpr:
+					// from: COMMON.PDAT.W
+					// to  : s.COMMON.PDAT.W
+					if id, ok := e.X.(*goast.Ident); ok && id.Name == commonName {
+						e.X = &goast.SelectorExpr{X: goast.NewIdent(recv), Sel: id}
+					}
+					// from: intrinsic.WRITE
+					// to  : s.UNITS.WRITE
+					if id, ok := e.X.(*goast.Ident); ok && id.Name == "intrinsic" && ioStatements[e.Sel.Name] {
+						e.X = &goast.SelectorExpr{X: goast.NewIdent(recv), Sel: goast.NewIdent(unitsName)}
+						units = true
+					}
+				case *goast.Ident:
+					// from: F
+					// to  : s.F
+					if funcs[e.Name] && !params[e.Name] {
+						return &goast.SelectorExpr{X: goast.NewIdent(recv), Sel: e}
+					}
+				}
+				return e
+			})
+		}
+	}
+
+	// struct State
+	var fields []*goast.Field
+	if units {
+		fields = append(fields, &goast.Field{
+			Names: []*goast.Ident{goast.NewIdent(unitsName)},
+			Type: &goast.StarExpr{X: &goast.SelectorExpr{
+				X:   goast.NewIdent("intrinsic"),
+				Sel: goast.NewIdent("Units"),
+			}},
+		})
+		for _, f := range files[1:] {
+			if !usesPackage(f, "intrinsic") {
+				removeFileImport(f, "github.com/Konstantin8105/f4go/intrinsic")
+			}
+		}
+		addFileImport(files[0], "github.com/Konstantin8105/f4go/intrinsic")
+	}
+	if len(blocks) > 0 {
+		var names []string
+		for name := range blocks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var memory []*goast.Field
+		for _, name := range names {
+			memory = append(memory, blocks[name])
+		}
+		fields = append(fields, &goast.Field{
+			Names: []*goast.Ident{goast.NewIdent(commonName)},
+			Type:  goast.NewIdent(memoryType),
+		})
+		addDecl(files[0], &goast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []goast.Spec{&goast.TypeSpec{
+				Name: goast.NewIdent(memoryType),
+				Type: &goast.StructType{Fields: &goast.FieldList{List: memory}},
+			}},
+		})
+	}
+	addDecl(files[0], &goast.GenDecl{
+		Doc: &goast.CommentGroup{List: []*goast.Comment{{
+			Text: "// State is global state of fortran program",
+		}}},
+		Tok: token.TYPE,
+		Specs: []goast.Spec{&goast.TypeSpec{
+			Name: goast.NewIdent(stateType),
+			Type: &goast.StructType{Fields: &goast.FieldList{List: fields}},
+		}},
+	})
+}
+
+// localNames return names of parameters and local variables of function
+//
+// Example:
+//
+//	func F(N *int) {
+//		X := new(float64)
+//		var Y int
+//	}
+//
+// names are N, X, Y
+func localNames(fd *goast.FuncDecl) map[string]bool {
+	names := map[string]bool{}
+	for _, field := range fd.Type.Params.List {
+		for _, n := range field.Names {
+			names[n.Name] = true
+		}
+	}
+	goast.Inspect(fd.Body, func(n goast.Node) bool {
+		switch n := n.(type) {
+		case *goast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				break
+			}
+			for _, e := range n.Lhs {
+				if id, ok := e.(*goast.Ident); ok {
+					names[id.Name] = true
+				}
+			}
+		case *goast.ValueSpec:
+			for _, id := range n.Names {
+				names[id.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// isMemory return true for declarations:
+//
+//	type MEMORY struct { ... }
+//	var COMMON MEMORY
+func isMemory(gen *goast.GenDecl) bool {
+	if len(gen.Specs) != 1 {
+		return false
+	}
+	switch spec := gen.Specs[0].(type) {
+	case *goast.TypeSpec:
+		return spec.Name.Name == memoryType
+	case *goast.ValueSpec:
+		return len(spec.Names) == 1 && spec.Names[0].Name == commonName
+	}
+	return false
+}
+
+// receiverName return name of receiver, which is not used in function
+func receiverName(fd *goast.FuncDecl) string {
+	used := map[string]bool{}
+	goast.Inspect(fd, func(n goast.Node) bool {
+		if id, ok := n.(*goast.Ident); ok {
+			used[id.Name] = true
+		}
+		return true
+	})
+	name := "s"
+	for used[name] {
+		name += "_"
+	}
+	return name
+}
+
+// addDecl add declaration after imports
+func addDecl(f *goast.File, decl goast.Decl) {
+	pos := 0
+	for pos < len(f.Decls) {
+		if gen, ok := f.Decls[pos].(*goast.GenDecl); !ok || gen.Tok != token.IMPORT {
+			break
+		}
+		pos++
+	}
+	f.Decls = append(f.Decls[:pos], append([]goast.Decl{decl}, f.Decls[pos:]...)...)
+}
+
+// usesPackage return true, if package is used in file
+func usesPackage(f *goast.File, name string) (used bool) {
+	goast.Inspect(f, func(n goast.Node) bool {
+		switch n := n.(type) {
+		case *goast.ImportSpec:
+			return false
+		case *goast.SelectorExpr:
+			if id, ok := n.X.(*goast.Ident); ok && id.Name == name {
+				used = true
+			}
+		case *goast.Ident:
+			// Go code inside Ident
+			if strings.Contains(n.Name, name+".") {
+				used = true
+			}
+		}
+		return !used
+	})
+	return
+}
+
+// removeFileImport remove import of package from file
+func removeFileImport(f *goast.File, pkg string) {
+	path := strconv.Quote(pkg)
+	var decls []goast.Decl
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*goast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+		var specs []goast.Spec
+		for _, spec := range gen.Specs {
+			if is, ok := spec.(*goast.ImportSpec); ok && is.Path.Value == path {
+				continue
+			}
+			specs = append(specs, spec)
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		gen.Specs = specs
+		decls = append(decls, gen)
+	}
+	f.Decls = decls
+	var imports []*goast.ImportSpec
+	for _, is := range f.Imports {
+		if is.Path.Value != path {
+			imports = append(imports, is)
+		}
+	}
+	f.Imports = imports
+}
@@ -0,0 +1,101 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReentrant(t *testing.T) {
+	srcs := []string{`
+      PROGRAM MAIN
+      INTEGER N
+      COMMON /BLK/ N
+      N = 5
+      CALL ADD1
+      END
+`, `
+      SUBROUTINE ADD1
+      INTEGER N
+      COMMON /BLK/ N
+      N = N + 1
+      END
+`}
+	out := parseFiles(t, srcs, Reentrant)
+
+	for i, ss := range [][]string{{
+		"type State struct",
+		"type MEMORY struct",
+		"s := new(State)",
+		"if s.COMMON.BLK.N == nil",
+		"s.ADD1()",
+	}, {
+		"func (s *State) ADD1()",
+		"N = s.COMMON.BLK.N",
+	}} {
+		for _, s := range ss {
+			if !strings.Contains(out[i], s) {
+				t.Errorf("Cannot find `%s` in:\n%s", s, out[i])
+			}
+		}
+	}
+	if strings.Contains(out[1], "MEMORY") {
+		t.Errorf("MEMORY must be only in first file:\n%s", out[1])
+	}
+}
+
+func TestReentrantUnits(t *testing.T) {
+	srcs := []string{`
+      PROGRAM MAIN
+      CALL OUT(5)
+      END
+`, `
+      SUBROUTINE OUT(N)
+      INTEGER N
+      WRITE(*, '(I5)') N
+      END
+`}
+	out := parseFiles(t, srcs, Reentrant)
+	for i, ss := range [][]string{{
+		`import "github.com/Konstantin8105/f4go/intrinsic"`,
+		"UNITS *intrinsic.Units",
+	}, {
+		`s.UNITS.WRITE(6, nil, []byte("(I5)"), (*(N)))`,
+	}} {
+		for _, s := range ss {
+			if !strings.Contains(out[i], s) {
+				t.Errorf("Cannot find `%s` in:\n%s", s, out[i])
+			}
+		}
+	}
+	if strings.Contains(out[1], "import") {
+		t.Errorf("Import of intrinsic is not used:\n%s", out[1])
+	}
+}
+
+func TestReentrantLocal(t *testing.T) {
+	srcs := []string{`
+      DOUBLE PRECISION FUNCTION DSDOT(X)
+      DOUBLE PRECISION X
+      DSDOT = X
+      END
+`, `
+      REAL FUNCTION SDSDOT(SB)
+      REAL SB
+      DOUBLE PRECISION DSDOT
+      DSDOT = SB
+      SDSDOT = DSDOT
+      END
+`}
+	out := parseFiles(t, srcs, Reentrant)[1]
+	for _, s := range []string{
+		"DSDOT := new(float64)",
+		"(*DSDOT) = (*(SB))",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "s.DSDOT") {
+		t.Errorf("Local variable is changed to function:\n%s", out)
+	}
+}
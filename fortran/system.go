@@ -0,0 +1,214 @@
+package fortran
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/token"
+	"strings"
+)
+
+// systemProcedure is system, time or command-line intrinsic procedure.
+// Types of arguments:
+//  int, []byte, bool - input value
+//  CHARACTER         - CHARACTER variable for result
+//  *T, []T           - INTEGER variable or array for result, T is type
+//                      parameter of procedure
+//  *float64          - REAL variable for result
+//  ...               - prefix of optional variadic argument
+type systemProcedure struct {
+	keywords []string // names of arguments
+	args     []string // types of arguments
+	optional int      // amount of optional last arguments
+	result   string   // type of result of function
+}
+
+// systemFunctions is system, time and command-line intrinsic
+// procedures
+var systemFunctions = map[string]systemProcedure{
+	"IARGC":                  {result: "int"},
+	"COMMAND_ARGUMENT_COUNT": {result: "int"},
+	"GETARG": {keywords: []string{"POS", "VALUE"},
+		args: []string{"int", "CHARACTER"}},
+	"GET_COMMAND": {keywords: []string{"COMMAND", "LENGTH", "STATUS"},
+		args: []string{"CHARACTER", "*T", "*T"}, optional: 3},
+	"GET_COMMAND_ARGUMENT": {keywords: []string{"NUMBER", "VALUE", "LENGTH", "STATUS"},
+		args: []string{"int", "CHARACTER", "*T", "*T"}, optional: 3},
+	"GETENV": {keywords: []string{"NAME", "VALUE"},
+		args: []string{"[]byte", "CHARACTER"}},
+	"GET_ENVIRONMENT_VARIABLE": {
+		keywords: []string{"NAME", "VALUE", "LENGTH", "STATUS", "TRIM_NAME"},
+		args:     []string{"[]byte", "CHARACTER", "*T", "*T", "...bool"}, optional: 4},
+	"CPU_TIME": {keywords: []string{"TIME"}, args: []string{"*float64"}},
+	"SYSTEM_CLOCK": {keywords: []string{"COUNT", "COUNT_RATE", "COUNT_MAX"},
+		args: []string{"*T", "*T", "*T"}, optional: 3},
+	"DATE_AND_TIME": {keywords: []string{"DATE", "TIME", "ZONE", "VALUES"},
+		args: []string{"CHARACTER", "CHARACTER", "CHARACTER", "[]T"}, optional: 4},
+	"ETIME": {keywords: []string{"TARRAY", "RESULT"},
+		args: []string{"[]float64", "...*float64"}, optional: 1, result: "float64"},
+	"DTIME": {keywords: []string{"TARRAY", "RESULT"},
+		args: []string{"[]float64", "...*float64"}, optional: 1, result: "float64"},
+	"SYSTEM": {keywords: []string{"COMMAND", "STATUS"},
+		args: []string{"[]byte", "...*int"}, optional: 1, result: "int"},
+	"EXIT": {keywords: []string{"STATUS"}, args: []string{"...int"}, optional: 1},
+}
+
+func init() {
+	for name, s := range systemFunctions {
+		specificFunctions[name] = specific{result: s.result}
+		intrinsicFunction[name] = systemIntrinsic
+	}
+}
+
+// systemIntrinsic change call of system, time or command-line
+// intrinsic procedure. Absent optional arguments are nil.
+//
+// Example:
+//  IARGC()                     - intrinsic.IARGC()
+//  CALL GETARG(1, ARG)         - intrinsic.GETARG(1, (*ARG))
+//  CALL SYSTEM_CLOCK(C, R)     - intrinsic.SYSTEM_CLOCK[int](C, R, nil)
+//  CALL DATE_AND_TIME(VALUES=V) - intrinsic.DATE_AND_TIME[int](nil, nil, nil, (*V))
+//  CALL EXIT(2)                - intrinsic.EXIT(2)
+func systemIntrinsic(p *parser, f *goast.CallExpr) {
+	id, ok := f.Fun.(*goast.Ident)
+	if !ok || id.Name != strings.ToUpper(id.Name) {
+		return
+	}
+	name := id.Name
+	s := systemFunctions[name]
+	if len(f.Args) < len(s.args)-s.optional || len(s.args) < len(f.Args) {
+		p.addError(fmt.Sprintf("Not valid amount of arguments for %s: %d", name, len(f.Args)))
+		return
+	}
+
+	var param string // type parameter with type of INTEGER results
+	args := make([]goast.Expr, 0, len(s.args))
+	for i, t := range s.args {
+		if len(f.Args) <= i || isNil(f.Args[i]) {
+			if strings.HasPrefix(t, "...") {
+				break
+			}
+			args = append(args, goast.NewIdent("nil"))
+			continue
+		}
+		t = strings.TrimPrefix(t, "...")
+		if strings.HasPrefix(t, "*") {
+			args = append(args, pointerArgument(f.Args[i]))
+			if t == "*T" && param == "" {
+				param = p.typeOf(f.Args[i])
+			}
+			continue
+		}
+		arg, typ := p.intrinsicArgument(f.Args[i])
+		switch t {
+		case "CHARACTER", "[]byte":
+			arg = characterValue(arg, typ)
+		case "[]T":
+			if param == "" {
+				param = strings.TrimPrefix(typ, "[]")
+			}
+		case "int", "bool":
+			if typ != "" && !isConstant(arg) {
+				arg = convert(arg, typ, t)
+			}
+		}
+		args = append(args, arg)
+	}
+
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	f.Args = args
+	f.Fun = &goast.SelectorExpr{
+		X:   goast.NewIdent("intrinsic"),
+		Sel: goast.NewIdent(name),
+	}
+	for _, t := range s.args {
+		if strings.HasSuffix(t, "T") {
+			if param == "" {
+				param = "int"
+			}
+			f.Fun = &goast.IndexExpr{X: f.Fun, Index: goast.NewIdent(param)}
+			break
+		}
+	}
+}
+
+// pointerArgument return pointer to variable for result of intrinsic
+// procedure
+//
+// Example:
+//  &((*J))     - J
+//  &((*A)[1])  - &(*A)[1]
+func pointerArgument(e goast.Expr) goast.Expr {
+	un, ok := e.(*goast.UnaryExpr)
+	if !ok || un.Op != token.AND {
+		return e
+	}
+	x := removeParen(un.X)
+	if st, ok := x.(*goast.StarExpr); ok {
+		return st.X
+	}
+	return &goast.UnaryExpr{Op: token.AND, X: x}
+}
+
+// isNil return true for absent argument
+//
+// Example:
+//  nil
+//  &((nil))
+func isNil(e goast.Expr) bool {
+	if un, ok := e.(*goast.UnaryExpr); ok && un.Op == token.AND {
+		e = un.X
+	}
+	id, ok := removeParen(e).(*goast.Ident)
+	return ok && id.Name == "nil"
+}
+
+// parseStop return statement STOP or ERROR STOP. Code of STOP is
+// INTEGER status or CHARACTER message.
+//
+// Example:
+//  STOP              - intrinsic.STOP()
+//  STOP 3            - intrinsic.STOP(3)
+//  STOP 'END'        - intrinsic.STOP_MESSAGE([]byte("END"))
+//  ERROR STOP        - intrinsic.ERROR_STOP()
+//  ERROR STOP 'FAIL' - intrinsic.ERROR_STOP_MESSAGE([]byte("FAIL"))
+func (p *parser) parseStop() goast.Stmt {
+	name := "STOP"
+	if p.ns[p.ident].tok == ftErrorStop {
+		name = "ERROR_STOP"
+	}
+	p.ident++
+	start := p.ident
+	for ; p.ident < len(p.ns) && p.ns[p.ident].tok != ftNewLine; p.ident++ {
+	}
+	code := p.ns[start:p.ident]
+
+	var args []goast.Expr
+	switch {
+	case len(code) == 0:
+	case len(code) == 1 && code[0].tok == token.STRING:
+		name += "_MESSAGE"
+		args = append(args, characterValue(&goast.BasicLit{
+			Kind:  token.STRING,
+			Value: string(code[0].b),
+		}, ""))
+	default:
+		e := p.parseExprNodes(code)
+		switch typ := p.typeOf(e); {
+		case typ == "[]byte" || typ == "byte":
+			name += "_MESSAGE"
+			e = characterValue(e, typ)
+		case typ != "" && !isConstant(e):
+			e = convert(e, typ, "int")
+		}
+		args = append(args, e)
+	}
+
+	p.addImport("github.com/Konstantin8105/f4go/intrinsic")
+	return &goast.ExprStmt{X: &goast.CallExpr{
+		Fun: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent(name),
+		},
+		Args: args,
+	}}
+}
@@ -0,0 +1,58 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSystemIntrinsic(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE SYS(N, V)
+      INTEGER N, V(8)
+      INTEGER*4 C, R
+      CHARACTER*20 ARG
+      CHARACTER*4 MSG
+      REAL T, TA(2)
+      N = IARGC() + COMMAND_ARGUMENT_COUNT()
+      CALL GETARG(N, ARG)
+      CALL GET_COMMAND_ARGUMENT(N, ARG, STATUS=R)
+      CALL GET_ENVIRONMENT_VARIABLE('HOME', ARG)
+      CALL SYSTEM_CLOCK(COUNT_RATE=R, COUNT=C)
+      CALL DATE_AND_TIME(VALUES=V)
+      CALL CPU_TIME(T)
+      T = ETIME(TA)
+      N = SYSTEM('ls')
+      IF (N .GT. 3) ERROR STOP 'FAIL'
+      IF (N .GT. 2) ERROR STOP
+      IF (N .GT. 1) STOP N
+      IF (N .GT. 1) STOP MSG
+      IF (N .GT. 1) ERROR STOP N
+      IF (N .LT. 0) STOP
+      IF (N .GT. 0) CALL EXIT(2)
+      STOP 'END'
+      END
+`)
+	for _, s := range []string{
+		`(*(N)) = intrinsic.IARGC() + intrinsic.COMMAND_ARGUMENT_COUNT()`,
+		`intrinsic.GETARG((*(N)), (*ARG))`,
+		`intrinsic.GET_COMMAND_ARGUMENT[int32]((*(N)), (*ARG), nil, R)`,
+		`intrinsic.GET_ENVIRONMENT_VARIABLE[int]([]byte("HOME"), (*ARG), nil, nil)`,
+		`intrinsic.SYSTEM_CLOCK[int32](C, R, nil)`,
+		`intrinsic.DATE_AND_TIME[int](nil, nil, nil, (*(V)))`,
+		`intrinsic.CPU_TIME(T)`,
+		`(*T) = intrinsic.ETIME((*TA))`,
+		`(*(N)) = intrinsic.SYSTEM([]byte("ls"))`,
+		`intrinsic.ERROR_STOP_MESSAGE([]byte("FAIL"))`,
+		`intrinsic.ERROR_STOP()`,
+		`intrinsic.STOP((*(N)))`,
+		`intrinsic.STOP_MESSAGE((*MSG))`,
+		`intrinsic.ERROR_STOP((*(N)))`,
+		`intrinsic.STOP()`,
+		`intrinsic.EXIT(2)`,
+		`intrinsic.STOP_MESSAGE([]byte("END"))`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
@@ -38,6 +38,7 @@ const (
 	ftIntrinsic
 	ftFormat
 	ftStop
+	ftErrorStop
 	ftDollar
 
 	ftStringConcat
@@ -50,6 +51,10 @@ const (
 	ftEquivalence
 	ftCommon
 	ftRewind
+	ftBackspace
+	ftEndfile
+	ftInquire
+	ftNamelist
 
 	ftInclude
 
@@ -97,6 +102,7 @@ var o = [...]string{
 	ftIntrinsic: "INTRINSIC",
 	ftFormat:    "FORMAT",
 	ftStop:      "STOP",
+	ftErrorStop: "ERROR STOP",
 
 	ftStringConcat: "STRING_CONCAT",
 	ftSave:         "SAVE",
@@ -111,6 +117,10 @@ var o = [...]string{
 	ftEquivalence: "EQUIVALENCE",
 	ftCommon:      "COMMON",
 	ftRewind:      "REWIND",
+	ftBackspace:   "BACKSPACE",
+	ftEndfile:     "ENDFILE",
+	ftInquire:     "INQUIRE",
+	ftNamelist:    "NAMELIST",
 
 	ftInclude: "INCLUDE",
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/token"
 	"strconv"
+	"strings"
 )
 
 func (g goType) isArray() bool {
@@ -17,6 +18,15 @@ func (g goType) isArray() bool {
 type goType struct {
 	baseType  string
 	arrayNode [][]node
+	length    []node // length of CHARACTER, empty for CHARACTER*(*)
+	single    bool   // REAL of single precision, Go type is float64
+}
+
+// character is Go type of CHARACTER
+const character = "intrinsic.Character"
+
+func (g goType) isCharacter() bool {
+	return g.baseType == character
 }
 
 func (g goType) getMinLimit(col int) (size int, ok bool) {
@@ -96,6 +106,10 @@ func fixType(nodes *[]node) {
 		return
 	}
 	start := end
+	if (*nodes)[start-1].tok == token.MUL {
+		// for: "CHARACTER * ( N ) ( 3 )"
+		return
+	}
 	for ; end < len(*nodes); end++ {
 		if (*nodes)[end].tok == token.LPAREN {
 			counter++
@@ -143,20 +157,14 @@ func parseType(nodes []node) (typ goType) {
 	switch nodes[0].tok {
 	case ftCharacter:
 		// CHARACTER
-		typ.baseType = "byte"
+		typ.baseType = character
+		typ.length = []node{{tok: token.INT, b: []byte("1")}}
 		nodes = nodes[1:]
 
-		// CHARACTER * n,
-		// n > 0
-		if len(nodes) > 1 &&
-			nodes[0].tok == token.MUL && nodes[1].tok == token.INT {
-			if string(nodes[1].b) != "1" {
-				typ.baseType = "byte" //"string"
-				typ.arrayNode = append(typ.arrayNode, []node{nodes[1]})
-			}
-			nodes = nodes[2:]
-		} else if len(nodes) > 0 && nodes[0].tok == token.MUL {
-			nodes = nodes[1:]
+		// CHARACTER * n
+		// CHARACTER * n * m - for "CHARACTER * n NAME * m"
+		for len(nodes) > 1 && nodes[0].tok == token.MUL {
+			typ.length, nodes = parseLength(nodes)
 		}
 	case ftComplex:
 		// COMPLEX or COMPLEX * 8
@@ -194,6 +202,7 @@ func parseType(nodes []node) (typ goType) {
 	case ftReal:
 		// REAL or REAL * 4
 		typ.baseType = "float64" // TODO : correct type "float32"
+		typ.single = true
 		nodes = nodes[1:]
 		if len(nodes) > 1 &&
 			nodes[0].tok == token.MUL &&
@@ -203,6 +212,7 @@ func parseType(nodes []node) (typ goType) {
 				typ.baseType = "float64" // TODO: for minimaze type convection "float32"
 			case "8": // REAL * 8
 				typ.baseType = "float64"
+				typ.single = false
 			default:
 				// REAL * 16
 				panic(fmt.Errorf(
@@ -271,5 +281,48 @@ func parseType(nodes []node) (typ goType) {
 	}
 
 	nodes = nodes[end:]
+
+	// CHARACTER NAME ( 3 ) * 8
+	if typ.isCharacter() && len(nodes) > 1 && nodes[0].tok == token.MUL {
+		typ.length, _ = parseLength(nodes)
+	}
+	return
+}
+
+// parseLength return length of CHARACTER and other nodes
+//
+// Example:
+//  * n
+//  * N
+//  * ( * )
+//  * ( N + 1 )
+//  * ( LEN = 8 )
+func parseLength(nodes []node) (length []node, other []node) {
+	switch nodes[1].tok {
+	case token.INT, token.IDENT:
+		return nodes[1:2], nodes[2:]
+	case token.LPAREN:
+		args, end := separateArgsParen(nodes[1:])
+		if len(args) == 1 && !(len(args[0]) == 1 && args[0][0].tok == token.MUL) {
+			length = args[0]
+		}
+		if len(length) > 2 && length[1].tok == token.ASSIGN &&
+			strings.ToUpper(string(length[0].b)) == "LEN" {
+			length = length[2:]
+		}
+		return length, nodes[1+end:]
+	}
+	return nil, nodes[1:]
+}
+
+// sliceString is same as String, but without sizes of arrays.
+// Example:
+//  from: [2][3]float64
+//  to  : [][]float64
+func (g goType) sliceString() (s string) {
+	s = g.getBaseType()
+	for i := 0; i < len(g.arrayNode); i++ {
+		s = "[]" + s
+	}
 	return
 }
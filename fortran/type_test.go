@@ -24,7 +24,7 @@ func TestParseType(t *testing.T) {
 				{tok: token.MUL, b: []byte("*")},
 				{tok: token.RPAREN, b: []byte(")")},
 			},
-			typ: "[]byte",
+			typ: "intrinsic.Character",
 		},
 		{
 			nodes: []node{
@@ -32,7 +32,7 @@ func TestParseType(t *testing.T) {
 				{tok: token.MUL, b: []byte("*")},
 				{tok: token.INT, b: []byte("32")},
 			},
-			typ: "[32]byte",
+			typ: "intrinsic.Character",
 		},
 		{
 			nodes: []node{
@@ -66,7 +66,7 @@ func TestParseType(t *testing.T) {
 				{tok: token.INT, b: []byte("32")},
 				{tok: token.RPAREN, b: []byte(")")},
 			},
-			typ: "[32]byte",
+			typ: "[32]intrinsic.Character",
 		},
 	}
 
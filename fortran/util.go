@@ -185,7 +185,7 @@ func (p *parser) split(nodes *[]node, pos int) (
 			// byte (...)
 			isByte := false
 			if v, ok := p.initVars.get(string(rightPart[rightSeparator].b)); ok {
-				if v.typ.baseType == "byte" && !v.typ.isArray() {
+				if v.typ.isCharacter() && !v.typ.isArray() {
 					if rightSeparator+1 < len(rightPart) &&
 						rightPart[rightSeparator+1].tok == token.LPAREN {
 						rightSeparator++
@@ -213,6 +213,10 @@ func (p *parser) split(nodes *[]node, pos int) (
 
 			if !p.isVariable(string(rightPart[rightSeparator].b)) {
 				// function
+				if rightSeparator+1 < len(rightPart) &&
+					rightPart[rightSeparator+1].tok == token.LPAREN {
+					rightSeparator++
+				}
 				counter := 0
 				for {
 					if rightPart[rightSeparator].tok == token.LPAREN {
@@ -229,10 +233,13 @@ func (p *parser) split(nodes *[]node, pos int) (
 			} else {
 				isArray := false
 				if v, ok := p.initVars.get(string(rightPart[rightSeparator].b)); ok {
-					isArray = v.typ.isArray()
+					isArray = v.typ.isArray() || v.typ.isCharacter()
 				}
-				if isArray {
-					// it is array
+				// it is array or substring:
+				//  A [ I ] [ J ]
+				//  S [ I : J ]
+				for isArray && rightSeparator+1 < len(rightPart) &&
+					rightPart[rightSeparator+1].tok == token.LBRACK {
 					counter := 0
 					rightSeparator++
 					if rightSeparator+1 <= len(rightPart) {
@@ -49,8 +49,8 @@ func TestSplit(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.in, func(t *testing.T) {
 			var p parser
-			p.initVars.add("a", goType{baseType: "byte"})
-			p.initVars.add("b", goType{baseType: "byte"})
+			p.initVars.add("a", goType{baseType: character})
+			p.initVars.add("b", goType{baseType: character})
 
 			nodes := scan([]byte(tc.in))
 			leftOther, leftVariable, rightVariable, rightOther :=
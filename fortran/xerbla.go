@@ -0,0 +1,300 @@
+package fortran
+
+import (
+	goast "go/ast"
+	"go/token"
+	"strconv"
+)
+
+// ErrorReturns changes subroutines with argument checking by XERBLA
+// to functions with error result. All files of one package must be
+// changed together, because calls of changed subroutines are changed
+// in all files.
+//
+// Rules:
+//   - subroutine with call of XERBLA returns error;
+//   - subroutine with argument INFO and call of subroutine, which
+//     returns error, also returns error;
+//   - XERBLA is replaced by intrinsic.XERBLA with routine name and
+//     number of argument, which is returned;
+//   - error of subroutine call is returned by caller, if caller
+//     returns error, otherwise it is a panic like STOP in XERBLA.
+//
+// Example, from:
+//
+//	func DGETRF(M *int, ..., INFO *int) {
+//		...
+//		XERBLA(func() *intrinsic.Character { y := intrinsic.Character("DGETRF"); return &y }(), -(*(INFO)))
+//		return
+//		...
+//		DGETF2(M, ..., INFO)
+//	}
+//
+// to:
+//
+//	func DGETRF(M *int, ..., INFO *int) error {
+//		...
+//		return intrinsic.XERBLA(intrinsic.Character("DGETRF"), -(*(INFO)))
+//		...
+//		if err := DGETF2(M, ..., INFO); err != nil {
+//			return err
+//		}
+//		return nil
+//	}
+func ErrorReturns(files []*goast.File) {
+	var subroutines []*goast.FuncDecl
+	for _, f := range files {
+		expandCode(f)
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*goast.FuncDecl)
+			if !ok || fd.Body == nil || fd.Type.Results != nil {
+				continue
+			}
+			subroutines = append(subroutines, fd)
+		}
+	}
+
+	funcs := map[string]*goast.FuncDecl{}
+	for _, fd := range subroutines {
+		if findCall(fd.Body, func(name string) bool { return name == xerbla }) {
+			funcs[fd.Name.Name] = fd
+		}
+	}
+	// subroutines with argument INFO return errors of called subroutines
+	for changed := true; changed; {
+		changed = false
+		for _, fd := range subroutines {
+			if _, ok := funcs[fd.Name.Name]; ok || !hasParam(fd, "INFO") {
+				continue
+			}
+			if findCall(fd.Body, func(name string) bool {
+				_, ok := funcs[name]
+				return ok
+			}) {
+				funcs[fd.Name.Name] = fd
+				changed = true
+			}
+		}
+	}
+	defer func() {
+		for _, f := range files {
+			collapseCode(f)
+		}
+	}()
+	if len(funcs) == 0 {
+		return
+	}
+
+	for _, f := range files {
+		used := false
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*goast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			_, isError := funcs[fd.Name.Name]
+			changeStmts(fd.Body, func(list []goast.Stmt) (out []goast.Stmt) {
+				for i := 0; i < len(list); i++ {
+					call, ok := callStmt(list[i])
+					if !ok {
+						out = append(out, list[i])
+						continue
+					}
+					name := call.Fun.(*goast.Ident).Name
+					if name == xerbla && isError {
+						// from: XERBLA(...); return
+						// to  : return intrinsic.XERBLA(...)
+						out = append(out, &goast.ReturnStmt{
+							Results: []goast.Expr{xerblaCall(call)},
+						})
+						if i+1 < len(list) {
+							if _, ok := list[i+1].(*goast.ReturnStmt); ok {
+								i++
+							}
+						}
+						used = true
+						continue
+					}
+					if _, ok := funcs[name]; !ok {
+						out = append(out, list[i])
+						continue
+					}
+					out = append(out, checkError(call, isError))
+				}
+				return
+			})
+			if !isError {
+				continue
+			}
+
+			fd.Type.Results = &goast.FieldList{List: []*goast.Field{{
+				Type: goast.NewIdent("error"),
+			}}}
+			goast.Walk(returnNil{}, fd.Body)
+			if _, ok := lastStmt(fd.Body).(*goast.ReturnStmt); !ok {
+				fd.Body.List = append(fd.Body.List, &goast.ReturnStmt{
+					Results: []goast.Expr{goast.NewIdent("nil")},
+				})
+			}
+		}
+		if used {
+			addFileImport(f, "github.com/Konstantin8105/f4go/intrinsic")
+		}
+	}
+}
+
+const xerbla = "XERBLA"
+
+// findCall return true if body have call of function with name
+// accepted by function f
+func findCall(body *goast.BlockStmt, f func(name string) bool) (found bool) {
+	goast.Inspect(body, func(n goast.Node) bool {
+		if _, ok := n.(*goast.FuncLit); ok {
+			return false
+		}
+		if call, ok := callStmt(n); ok {
+			if f(call.Fun.(*goast.Ident).Name) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return
+}
+
+// hasParam return true if function have parameter with name
+func hasParam(fd *goast.FuncDecl, name string) bool {
+	for _, field := range fd.Type.Params.List {
+		for _, n := range field.Names {
+			if n.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callStmt return call of function for statement like:
+//
+//	FUNC(...)
+func callStmt(n goast.Node) (call *goast.CallExpr, ok bool) {
+	e, ok := n.(*goast.ExprStmt)
+	if !ok {
+		return nil, false
+	}
+	call, ok = e.X.(*goast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := call.Fun.(*goast.Ident); !ok {
+		return nil, false
+	}
+	return call, true
+}
+
+// xerblaCall return call of intrinsic.XERBLA with values of arguments
+func xerblaCall(call *goast.CallExpr) goast.Expr {
+	args := make([]goast.Expr, len(call.Args))
+	for i := range call.Args {
+		args[i] = valueOf(call.Args[i])
+	}
+	return &goast.CallExpr{
+		Fun: &goast.SelectorExpr{
+			X:   goast.NewIdent("intrinsic"),
+			Sel: goast.NewIdent(xerbla),
+		},
+		Args: args,
+	}
+}
+
+// checkError return statement with checking error of call
+//
+//	if err := FUNC(...); err != nil {
+//		return err
+//	}
+func checkError(call *goast.CallExpr, isReturn bool) goast.Stmt {
+	var stmt goast.Stmt = &goast.ReturnStmt{
+		Results: []goast.Expr{goast.NewIdent("err")},
+	}
+	if !isReturn {
+		stmt = &goast.ExprStmt{X: &goast.CallExpr{
+			Fun:  goast.NewIdent("panic"),
+			Args: []goast.Expr{goast.NewIdent("err")},
+		}}
+	}
+	return &goast.IfStmt{
+		Init: &goast.AssignStmt{
+			Lhs: []goast.Expr{goast.NewIdent("err")},
+			Tok: token.DEFINE,
+			Rhs: []goast.Expr{call},
+		},
+		Cond: &goast.BinaryExpr{
+			X:  goast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  goast.NewIdent("nil"),
+		},
+		Body: &goast.BlockStmt{List: []goast.Stmt{stmt}},
+	}
+}
+
+// go/ast Visitor for change empty return statements to "return nil"
+type returnNil struct{}
+
+func (r returnNil) Visit(node goast.Node) (w goast.Visitor) {
+	switch n := node.(type) {
+	case *goast.FuncLit:
+		return nil
+	case *goast.ReturnStmt:
+		if len(n.Results) == 0 {
+			n.Results = []goast.Expr{goast.NewIdent("nil")}
+		}
+	}
+	return r
+}
+
+// changeStmts change all lists of statements, except function literals
+func changeStmts(node goast.Node, f func([]goast.Stmt) []goast.Stmt) {
+	goast.Inspect(node, func(n goast.Node) bool {
+		switch n := n.(type) {
+		case *goast.FuncLit:
+			return false
+		case *goast.BlockStmt:
+			n.List = f(n.List)
+		case *goast.CaseClause:
+			n.Body = f(n.Body)
+		}
+		return true
+	})
+}
+
+// lastStmt return last statement of body without comments
+func lastStmt(body *goast.BlockStmt) goast.Stmt {
+	for i := len(body.List) - 1; i >= 0; i-- {
+		if !isComment(body.List[i]) {
+			return body.List[i]
+		}
+	}
+	return nil
+}
+
+// addFileImport add import of package in file, if it is not exist
+func addFileImport(f *goast.File, pkg string) {
+	path := strconv.Quote(pkg)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*goast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if is, ok := spec.(*goast.ImportSpec); ok && is.Path.Value == path {
+				return
+			}
+		}
+	}
+	f.Decls = append([]goast.Decl{&goast.GenDecl{
+		Tok: token.IMPORT,
+		Specs: []goast.Spec{&goast.ImportSpec{
+			Path: &goast.BasicLit{Kind: token.STRING, Value: path},
+		}},
+	}}, f.Decls...)
+}
@@ -0,0 +1,44 @@
+package fortran
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestErrorReturns(t *testing.T) {
+	out := parseIO(t, `
+      SUBROUTINE SETV(N, INFO)
+      INTEGER N, INFO
+      INFO = 0
+      IF (N .LT. 0) THEN
+         INFO = -1
+         CALL XERBLA('SETV  ', -INFO)
+         RETURN
+      END IF
+      END
+
+      SUBROUTINE WRAPV(N, INFO)
+      INTEGER N, INFO
+      CALL SETV(N, INFO)
+      END
+
+      PROGRAM MAIN
+      INTEGER INFO
+      CALL SETV(-1, INFO)
+      END
+`, ErrorReturns)
+
+	for _, s := range []string{
+		"func SETV(N *int, INFO *int) error",
+		`return intrinsic.XERBLA(intrinsic.Character("SETV  "), -(*(INFO)))`,
+		"func WRAPV(N *int, INFO *int) error",
+		"return err",
+		"return nil",
+		"panic(err)",
+		`"github.com/Konstantin8105/f4go/intrinsic"`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Cannot find `%s` in:\n%s", s, out)
+		}
+	}
+}
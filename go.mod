@@ -1,6 +1,6 @@
 module github.com/Konstantin8105/f4go
 
-go 1.13
+go 1.18
 
 require (
 	github.com/bradleyjkemp/cupaloy v2.3.0+incompatible
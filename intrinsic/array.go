@@ -0,0 +1,637 @@
+package intrinsic
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Array intrinsic functions of Fortran 90. Array is nested slices,
+// where first index of Go is first subscript of Fortran, so A(I,J) is
+// A[I-1][J-1]. Elements are taken in array element order of Fortran,
+// first subscript is changed fastest. Result has type of elements of
+// argument and rank is defined by arguments, so type of result is
+// parameter R:
+//
+//	SUM[float64](A, 0, nil)    - sum of all elements of A(3,4)
+//	SUM[[]float64](A, 1, nil)  - sums of 4 columns
+//	MAXLOC[[]int](A, 0, L)     - subscripts of maximal element, if L
+//
+// Absent DIM is 0, absent MASK is nil. MASK is array of bool with
+// shape of array or one bool value.
+
+// array is Fortran array with elements in array element order
+type array struct {
+	typ   reflect.Type // type of elements
+	shape []int
+	data  []reflect.Value
+}
+
+// arrayOf return array of nested slices or of one value. Named
+// slices like Character are elements.
+func arrayOf(a interface{}) (x array) {
+	v := reflect.ValueOf(a)
+	x.typ = v.Type()
+	for e := v; x.typ.Kind() == reflect.Slice && x.typ.Name() == ""; x.typ = x.typ.Elem() {
+		x.shape = append(x.shape, e.Len())
+		if e.Len() > 0 {
+			e = e.Index(0)
+		} else {
+			e = reflect.Zero(x.typ.Elem())
+		}
+	}
+	x.data = make([]reflect.Value, x.size())
+	for i := range x.data {
+		e := v
+		for _, j := range x.index(i) {
+			e = e.Index(j)
+		}
+		x.data[i] = e
+	}
+	return
+}
+
+// newArray return array with zero values
+func newArray(typ reflect.Type, shape []int) array {
+	x := array{typ: typ, shape: shape}
+	x.data = make([]reflect.Value, x.size())
+	for i := range x.data {
+		x.data[i] = reflect.Zero(typ)
+	}
+	return x
+}
+
+// size return amount of elements
+func (x array) size() int {
+	size := 1
+	for _, n := range x.shape {
+		size *= n
+	}
+	return size
+}
+
+// index return subscripts from zero of element with position i in
+// array element order
+func (x array) index(i int) []int {
+	index := make([]int, len(x.shape))
+	for k, n := range x.shape {
+		index[k] = i % n
+		i /= n
+	}
+	return index
+}
+
+// position return position of element in array element order
+func (x array) position(index []int) (i int) {
+	for k := len(x.shape) - 1; k >= 0; k-- {
+		i = i*x.shape[k] + index[k]
+	}
+	return
+}
+
+// value return nested slices or one value for array of rank 0
+func (x array) value() interface{} {
+	if len(x.shape) == 0 {
+		return x.data[0].Interface()
+	}
+	t := x.typ
+	for range x.shape {
+		t = reflect.SliceOf(t)
+	}
+	var alloc func(t reflect.Type, shape []int) reflect.Value
+	alloc = func(t reflect.Type, shape []int) reflect.Value {
+		s := reflect.MakeSlice(t, shape[0], shape[0])
+		if len(shape) > 1 {
+			for i := 0; i < shape[0]; i++ {
+				s.Index(i).Set(alloc(t.Elem(), shape[1:]))
+			}
+		}
+		return s
+	}
+	v := alloc(t, x.shape)
+	for i, d := range x.data {
+		e := v
+		for _, j := range x.index(i) {
+			e = e.Index(j)
+		}
+		e.Set(d)
+	}
+	return v.Interface()
+}
+
+// lines return shape of result and positions of elements for each
+// element of result. Line is elements along dimension dim or all
+// elements, if dim is 0.
+func (x array) lines(dim int) (shape []int, lines [][]int) {
+	if dim == 0 {
+		line := make([]int, x.size())
+		for i := range line {
+			line[i] = i
+		}
+		return nil, [][]int{line}
+	}
+	if dim < 1 || len(x.shape) < dim {
+		panic(fmt.Errorf("Not valid DIM %d for array of rank %d", dim, len(x.shape)))
+	}
+	stride, n := 1, x.shape[dim-1]
+	for _, s := range x.shape[:dim-1] {
+		stride *= s
+	}
+	shape = append(append([]int{}, x.shape[:dim-1]...), x.shape[dim:]...)
+	for r := 0; r < (array{shape: shape}).size(); r++ {
+		base := r%stride + r/stride*stride*n
+		line := make([]int, n)
+		for j := range line {
+			line[j] = base + j*stride
+		}
+		lines = append(lines, line)
+	}
+	return
+}
+
+// reduce return result of function for each line along dimension dim
+func (x array) reduce(dim int, typ reflect.Type, f func(line []int) reflect.Value) interface{} {
+	shape, lines := x.lines(dim)
+	r := array{typ: typ, shape: shape, data: make([]reflect.Value, len(lines))}
+	for i, line := range lines {
+		r.data[i] = f(line)
+	}
+	return r.value()
+}
+
+// maskOf return function of mask for position of element
+func maskOf(mask interface{}) func(i int) bool {
+	switch m := mask.(type) {
+	case nil:
+		return func(int) bool { return true }
+	case bool:
+		return func(int) bool { return m }
+	}
+	m := arrayOf(mask)
+	return func(i int) bool { return m.data[i].Bool() }
+}
+
+// arithmetic return result of operation op for values of same type
+func arithmetic(op byte, a, b reflect.Value) reflect.Value {
+	var r interface{}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if op == '+' {
+			r = a.Int() + b.Int()
+		} else {
+			r = a.Int() * b.Int()
+		}
+	case reflect.Float32, reflect.Float64:
+		if op == '+' {
+			r = a.Float() + b.Float()
+		} else {
+			r = a.Float() * b.Float()
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if op == '+' {
+			r = a.Complex() + b.Complex()
+		} else {
+			r = a.Complex() * b.Complex()
+		}
+	default:
+		panic(fmt.Errorf("Type %v is not numeric", a.Type()))
+	}
+	return reflect.ValueOf(r).Convert(a.Type())
+}
+
+// less return true, if a < b
+func less(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	}
+	return a.Int() < b.Int()
+}
+
+// one return value 1 of type
+func one(typ reflect.Type) reflect.Value {
+	if typ.Kind() == reflect.Complex64 || typ.Kind() == reflect.Complex128 {
+		return reflect.ValueOf(complex(1, 0)).Convert(typ)
+	}
+	return reflect.ValueOf(1).Convert(typ)
+}
+
+// lowest return negative value with largest magnitude of type
+func lowest(typ reflect.Type) reflect.Value {
+	switch typ.Kind() {
+	case reflect.Float32:
+		return reflect.ValueOf(-math.MaxFloat32).Convert(typ)
+	case reflect.Float64:
+		return reflect.ValueOf(-math.MaxFloat64).Convert(typ)
+	}
+	return reflect.ValueOf(int64(-1) << uint(typ.Bits()-1)).Convert(typ)
+}
+
+// highest return positive value with largest magnitude of type
+func highest(typ reflect.Type) reflect.Value {
+	switch typ.Kind() {
+	case reflect.Float32:
+		return reflect.ValueOf(math.MaxFloat32).Convert(typ)
+	case reflect.Float64:
+		return reflect.ValueOf(math.MaxFloat64).Convert(typ)
+	}
+	return reflect.ValueOf(int64(mask(typ.Bits() - 1))).Convert(typ)
+}
+
+// SUM is sum of elements
+func SUM[R any](a interface{}, dim int, mask interface{}) R {
+	x, m := arrayOf(a), maskOf(mask)
+	return x.reduce(dim, x.typ, func(line []int) reflect.Value {
+		s := reflect.Zero(x.typ)
+		for _, i := range line {
+			if m(i) {
+				s = arithmetic('+', s, x.data[i])
+			}
+		}
+		return s
+	}).(R)
+}
+
+// PRODUCT is product of elements
+func PRODUCT[R any](a interface{}, dim int, mask interface{}) R {
+	x, m := arrayOf(a), maskOf(mask)
+	return x.reduce(dim, x.typ, func(line []int) reflect.Value {
+		s := one(x.typ)
+		for _, i := range line {
+			if m(i) {
+				s = arithmetic('*', s, x.data[i])
+			}
+		}
+		return s
+	}).(R)
+}
+
+// extremum return position in line of first maximal element or -1
+func (x array) extremum(line []int, m func(int) bool, max bool) (pos int) {
+	pos = -1
+	for j, i := range line {
+		if !m(i) {
+			continue
+		}
+		if pos < 0 || (max && less(x.data[line[pos]], x.data[i])) ||
+			(!max && less(x.data[i], x.data[line[pos]])) {
+			pos = j
+		}
+	}
+	return
+}
+
+// MAXVAL is maximal value of elements. Maximal value of zero
+// elements is negative value with largest magnitude.
+func MAXVAL[R any](a interface{}, dim int, mask interface{}) R {
+	x, m := arrayOf(a), maskOf(mask)
+	return x.reduce(dim, x.typ, func(line []int) reflect.Value {
+		if pos := x.extremum(line, m, true); pos >= 0 {
+			return x.data[line[pos]]
+		}
+		return lowest(x.typ)
+	}).(R)
+}
+
+// MINVAL is minimal value of elements. Minimal value of zero
+// elements is HUGE.
+func MINVAL[R any](a interface{}, dim int, mask interface{}) R {
+	x, m := arrayOf(a), maskOf(mask)
+	return x.reduce(dim, x.typ, func(line []int) reflect.Value {
+		if pos := x.extremum(line, m, false); pos >= 0 {
+			return x.data[line[pos]]
+		}
+		return highest(x.typ)
+	}).(R)
+}
+
+// location return subscripts of first extremal element or position
+// along dimension dim. Subscripts start from 1, zero is for zero
+// elements.
+func location(a interface{}, dim int, mask interface{}, max bool) interface{} {
+	x, m := arrayOf(a), maskOf(mask)
+	if dim == 0 {
+		loc := make([]int, len(x.shape))
+		_, lines := x.lines(0)
+		if pos := x.extremum(lines[0], m, max); pos >= 0 {
+			for k, i := range x.index(pos) {
+				loc[k] = i + 1
+			}
+		}
+		return loc
+	}
+	return x.reduce(dim, reflect.TypeOf(0), func(line []int) reflect.Value {
+		return reflect.ValueOf(x.extremum(line, m, max) + 1)
+	})
+}
+
+// MAXLOC is subscripts of first maximal element as []int or positions
+// along dimension dim
+func MAXLOC[R any](a interface{}, dim int, mask interface{}) R {
+	return location(a, dim, mask, true).(R)
+}
+
+// MINLOC is subscripts of first minimal element as []int or positions
+// along dimension dim
+func MINLOC[R any](a interface{}, dim int, mask interface{}) R {
+	return location(a, dim, mask, false).(R)
+}
+
+// COUNT is amount of true elements
+func COUNT[R any](mask interface{}, dim int) R {
+	x := arrayOf(mask)
+	return x.reduce(dim, reflect.TypeOf(0), func(line []int) reflect.Value {
+		var n int
+		for _, i := range line {
+			if x.data[i].Bool() {
+				n++
+			}
+		}
+		return reflect.ValueOf(n)
+	}).(R)
+}
+
+// ANY is true, if any element is true
+func ANY[R any](mask interface{}, dim int) R {
+	x := arrayOf(mask)
+	return x.reduce(dim, x.typ, func(line []int) reflect.Value {
+		for _, i := range line {
+			if x.data[i].Bool() {
+				return reflect.ValueOf(true)
+			}
+		}
+		return reflect.ValueOf(false)
+	}).(R)
+}
+
+// ALL is true, if all elements are true
+func ALL[R any](mask interface{}, dim int) R {
+	x := arrayOf(mask)
+	return x.reduce(dim, x.typ, func(line []int) reflect.Value {
+		for _, i := range line {
+			if !x.data[i].Bool() {
+				return reflect.ValueOf(false)
+			}
+		}
+		return reflect.ValueOf(true)
+	}).(R)
+}
+
+// DOT_PRODUCT is dot product of vectors. Elements of first COMPLEX
+// vector are conjugated, LOGICAL vectors are joined by AND and OR.
+func DOT_PRODUCT[R any](a, b interface{}) R {
+	x, y := arrayOf(a), arrayOf(b)
+	s := reflect.Zero(x.typ)
+	for i := range x.data {
+		v := x.data[i]
+		switch v.Kind() {
+		case reflect.Bool:
+			s = reflect.ValueOf(s.Bool() || v.Bool() && y.data[i].Bool())
+			continue
+		case reflect.Complex64, reflect.Complex128:
+			c := v.Complex()
+			v = reflect.ValueOf(complex(real(c), -imag(c))).Convert(x.typ)
+		}
+		s = arithmetic('+', s, arithmetic('*', v, y.data[i]))
+	}
+	return s.Interface().(R)
+}
+
+// MATMUL is product of matrices or of matrix and vector
+func MATMUL[R any](a, b interface{}) R {
+	x, y := arrayOf(a), arrayOf(b)
+	// vector is matrix with one row for x and one column for y
+	n, m := 1, x.shape[0]
+	if len(x.shape) == 2 {
+		n, m = x.shape[0], x.shape[1]
+	}
+	k := 1
+	if len(y.shape) == 2 {
+		k = y.shape[1]
+	}
+	if y.shape[0] != m {
+		panic(fmt.Errorf("Not valid shapes of MATMUL: %v and %v", x.shape, y.shape))
+	}
+	r := newArray(x.typ, []int{n, k})
+	for i := 0; i < n; i++ {
+		for j := 0; j < k; j++ {
+			s := reflect.Zero(x.typ)
+			for l := 0; l < m; l++ {
+				s = arithmetic('+', s, arithmetic('*', x.data[i+n*l], y.data[l+m*j]))
+			}
+			r.data[i+n*j] = s
+		}
+	}
+	switch {
+	case len(x.shape) == 1:
+		r.shape = []int{k}
+	case len(y.shape) == 1:
+		r.shape = []int{n}
+	}
+	return r.value().(R)
+}
+
+// TRANSPOSE is transposed matrix
+func TRANSPOSE[R any](a interface{}) R {
+	x := arrayOf(a)
+	n, m := x.shape[0], x.shape[1]
+	r := newArray(x.typ, []int{m, n})
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			r.data[j+m*i] = x.data[i+n*j]
+		}
+	}
+	return r.value().(R)
+}
+
+// SIZE is amount of elements or extent along dimension dim
+func SIZE(a interface{}, dim int) int {
+	x := arrayOf(a)
+	if dim != 0 {
+		return x.shape[dim-1]
+	}
+	return x.size()
+}
+
+// SHAPE is extents of array
+func SHAPE(a interface{}) []int {
+	return arrayOf(a).shape
+}
+
+// LBOUND is lower bounds of array declared with lower bounds lower or
+// lower bound along dimension dim. Lower bound is 1 for nil lower and
+// for dimension with zero extent.
+func LBOUND[R any](a interface{}, lower []int, dim int) R {
+	bounds := SHAPE(a)
+	for k, n := range bounds {
+		bounds[k] = 1
+		if n > 0 && lower != nil {
+			bounds[k] = lower[k]
+		}
+	}
+	if dim != 0 {
+		return interface{}(bounds[dim-1]).(R)
+	}
+	return interface{}(bounds).(R)
+}
+
+// UBOUND is upper bounds of array declared with lower bounds lower or
+// upper bound along dimension dim. Upper bound is 0 for dimension with
+// zero extent.
+func UBOUND[R any](a interface{}, lower []int, dim int) R {
+	bounds, lbounds := SHAPE(a), LBOUND[[]int](a, lower, 0)
+	for k, n := range bounds {
+		if n > 0 {
+			bounds[k] = lbounds[k] + n - 1
+		}
+	}
+	if dim != 0 {
+		return interface{}(bounds[dim-1]).(R)
+	}
+	return interface{}(bounds).(R)
+}
+
+// RESHAPE is array with shape and elements of source, then elements
+// of pad repeatedly
+func RESHAPE[R any](source interface{}, shape []int, pad interface{}) R {
+	x := arrayOf(source)
+	r := newArray(x.typ, append([]int{}, shape...))
+	data := x.data
+	if pad != nil {
+		for p := arrayOf(pad); len(data) < len(r.data) && len(p.data) > 0; {
+			data = append(data[:len(data):len(data)], p.data...)
+		}
+	}
+	if len(data) < len(r.data) {
+		panic(fmt.Errorf("Not enough elements for RESHAPE to %v", shape))
+	}
+	copy(r.data, data)
+	return r.value().(R)
+}
+
+// SPREAD is array with ncopies copies of source along dimension dim
+func SPREAD[R any](source interface{}, dim, ncopies int) R {
+	x := arrayOf(source)
+	if ncopies < 0 {
+		ncopies = 0
+	}
+	shape := append(append(append([]int{}, x.shape[:dim-1]...), ncopies), x.shape[dim-1:]...)
+	r := newArray(x.typ, shape)
+	for i := range r.data {
+		index := r.index(i)
+		r.data[i] = x.data[x.position(append(index[:dim-1:dim-1], index[dim:]...))]
+	}
+	return r.value().(R)
+}
+
+// PACK is vector of elements with true mask. If vector is not nil,
+// then result has size of vector and rest elements are from vector.
+func PACK[R any](a, mask, vector interface{}) R {
+	x, m := arrayOf(a), maskOf(mask)
+	r := array{typ: x.typ}
+	for i := range x.data {
+		if m(i) {
+			r.data = append(r.data, x.data[i])
+		}
+	}
+	if vector != nil {
+		v := arrayOf(vector)
+		r.data = append(r.data, v.data[len(r.data):]...)
+	}
+	r.shape = []int{len(r.data)}
+	return r.value().(R)
+}
+
+// UNPACK is array with shape of mask, elements are from vector for
+// true mask and from field for false mask. Field is array or one
+// value.
+func UNPACK[R any](vector, mask, field interface{}) R {
+	v, m, f := arrayOf(vector), arrayOf(mask), arrayOf(field)
+	r := newArray(v.typ, m.shape)
+	for i, next := range r.data {
+		switch {
+		case m.data[i].Bool():
+			next, v.data = v.data[0], v.data[1:]
+		case len(f.shape) == 0:
+			next = f.data[0].Convert(v.typ)
+		default:
+			next = f.data[i]
+		}
+		r.data[i] = next
+	}
+	return r.value().(R)
+}
+
+// CSHIFT is array with circular shift of elements along dimension
+// dim. Element of result with subscript j is element j+shift.
+func CSHIFT[R any](a interface{}, shift, dim int) R {
+	x := arrayOf(a)
+	r := newArray(x.typ, x.shape)
+	_, lines := x.lines(dim)
+	for _, line := range lines {
+		n := len(line)
+		for j := range line {
+			r.data[line[j]] = x.data[line[((j+shift)%n+n)%n]]
+		}
+	}
+	return r.value().(R)
+}
+
+// EOSHIFT is array with end-off shift of elements along dimension dim.
+// Vacated elements are boundary or zero, if boundary is nil.
+func EOSHIFT[R any](a interface{}, shift int, boundary interface{}, dim int) R {
+	x := arrayOf(a)
+	r := newArray(x.typ, x.shape)
+	b := reflect.Zero(x.typ)
+	if boundary != nil {
+		b = reflect.ValueOf(boundary).Convert(x.typ)
+	}
+	_, lines := x.lines(dim)
+	for _, line := range lines {
+		for j := range line {
+			r.data[line[j]] = b
+			if k := j + shift; 0 <= k && k < len(line) {
+				r.data[line[j]] = x.data[line[k]]
+			}
+		}
+	}
+	return r.value().(R)
+}
+
+// MERGE is tsource for true mask and fsource for false mask. Each
+// argument is array or one value, shape of result is shape of array
+// arguments.
+func MERGE[R any](tsource, fsource, mask interface{}) R {
+	var r R
+	typ := reflect.TypeOf(r)
+	for typ.Kind() == reflect.Slice && typ.Name() == "" {
+		typ = typ.Elem()
+	}
+	x := []array{arrayOf(tsource), arrayOf(fsource), arrayOf(mask)}
+	var shape []int
+	for _, a := range x {
+		if len(a.shape) > 0 {
+			shape = a.shape
+		}
+	}
+	m := newArray(typ, shape)
+	for i := range m.data {
+		element := func(a array) reflect.Value {
+			if len(a.shape) == 0 {
+				return a.data[0]
+			}
+			return a.data[i]
+		}
+		source := x[1]
+		if element(x[2]).Bool() {
+			source = x[0]
+		}
+		m.data[i] = element(source).Convert(typ)
+	}
+	return m.value().(R)
+}
+
+// ARRAY is array constructor (/ ... /)
+func ARRAY[T any](v ...T) []T {
+	return v
+}
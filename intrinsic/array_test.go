@@ -0,0 +1,97 @@
+package intrinsic
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestArray(t *testing.T) {
+	// A(2,3) with rows 1 2 3 and 4 5 6, L = A > 2
+	a := [][]int{{1, 2, 3}, {4, 5, 6}}
+	l := [][]bool{{false, false, true}, {true, true, true}}
+	v := []int{1, 2, 3, 4}
+	tcs := []struct {
+		name   string
+		result interface{}
+		expect interface{}
+	}{
+		{"SUM(A)", SUM[int](a, 0, nil), 21},
+		{"SUM(A,DIM=1)", SUM[[]int](a, 1, nil), []int{5, 7, 9}},
+		{"SUM(A,DIM=2)", SUM[[]int](a, 2, nil), []int{6, 15}},
+		{"SUM(A,MASK=L)", SUM[int](a, 0, l), 18},
+		{"SUM(A,1,L)", SUM[[]int](a, 1, l), []int{4, 5, 9}},
+		{"SUM(X)", SUM[float64]([]float64{0.5, 0.25}, 0, nil), 0.75},
+		{"SUM(A,MASK=.FALSE.)", SUM[int](a, 0, false), 0},
+		{"PRODUCT(A,DIM=2)", PRODUCT[[]int](a, 2, nil), []int{6, 120}},
+		{"PRODUCT(C)", PRODUCT[complex128]([]complex128{1i, 1i}, 0, nil), complex(-1, 0)},
+		{"MAXVAL(A,DIM=1)", MAXVAL[[]int](a, 1, nil), []int{4, 5, 6}},
+		{"MINVAL(A,DIM=2)", MINVAL[[]int](a, 2, nil), []int{1, 4}},
+		{"MINVAL(A,MASK=L)", MINVAL[int](a, 0, l), 3},
+		{"MAXVAL(X,MASK=.FALSE.)", MAXVAL[float64]([]float64{1}, 0, false), -math.MaxFloat64},
+		{"MAXVAL(I4,MASK=.FALSE.)", MAXVAL[int32]([]int32{1}, 0, false), int32(math.MinInt32)},
+		{"MINVAL(I4,MASK=.FALSE.)", MINVAL[int32]([]int32{1}, 0, false), int32(math.MaxInt32)},
+		{"MAXLOC(A)", MAXLOC[[]int](a, 0, nil), []int{2, 3}},
+		{"MINLOC(A)", MINLOC[[]int](a, 0, nil), []int{1, 1}},
+		{"MAXLOC(A,DIM=2)", MAXLOC[[]int](a, 2, nil), []int{3, 3}},
+		{"MINLOC(A,MASK=L)", MINLOC[[]int](a, 0, l), []int{1, 3}},
+		{"MAXLOC(V,MASK=.FALSE.)", MAXLOC[[]int](v, 0, false), []int{0}},
+		{"MAXLOC((/1,3,3/))", MAXLOC[[]int]([]int{1, 3, 3}, 0, nil), []int{2}},
+		{"COUNT(L)", COUNT[int](l, 0), 4},
+		{"COUNT(L,DIM=1)", COUNT[[]int](l, 1), []int{1, 1, 2}},
+		{"ANY(L,DIM=2)", ANY[[]bool](l, 2), []bool{true, true}},
+		{"ALL(L,DIM=1)", ALL[[]bool](l, 1), []bool{false, false, true}},
+		{"ALL(L)", ALL[bool](l, 0), false},
+		{"ANY(L)", ANY[bool](l, 0), true},
+		{"DOT_PRODUCT", DOT_PRODUCT[int]([]int{1, 2, 3}, []int{4, 5, 6}), 32},
+		{"DOT_PRODUCT(C)", DOT_PRODUCT[complex128]([]complex128{1i}, []complex128{1i}), complex(1, 0)},
+		{"DOT_PRODUCT(L)", DOT_PRODUCT[bool]([]bool{true, false}, []bool{false, true}), false},
+		{"MATMUL(A,TRANSPOSE(A))", MATMUL[[][]int](a, TRANSPOSE[[][]int](a)), [][]int{{14, 32}, {32, 77}}},
+		{"MATMUL(V,A)", MATMUL[[]int]([]int{1, 1}, a), []int{5, 7, 9}},
+		{"MATMUL(A,V)", MATMUL[[]int](a, []int{1, 1, 1}), []int{6, 15}},
+		{"TRANSPOSE(A)", TRANSPOSE[[][]int](a), [][]int{{1, 4}, {2, 5}, {3, 6}}},
+		{"SIZE(A)", SIZE(a, 0), 6},
+		{"SIZE(A,2)", SIZE(a, 2), 3},
+		{"SHAPE(A)", SHAPE(a), []int{2, 3}},
+		{"LBOUND(K(2:6))", LBOUND[[]int](make([]int, 5), []int{2}, 0), []int{2}},
+		{"UBOUND(K(2:6))", UBOUND[[]int](make([]int, 5), []int{2}, 0), []int{6}},
+		{"LBOUND(A)", LBOUND[[]int](a, nil, 0), []int{1, 1}},
+		{"UBOUND(A)", UBOUND[[]int](a, nil, 0), []int{2, 3}},
+		{"UBOUND(A,2)", UBOUND[int](a, nil, 2), 3},
+		{"LBOUND(K(2:6),1)", LBOUND[int](v, []int{2}, 1), 2},
+		{"LBOUND(K(5:4))", LBOUND[[]int]([]int{}, []int{5}, 0), []int{1}},
+		{"UBOUND(K(5:4))", UBOUND[[]int]([]int{}, []int{5}, 0), []int{0}},
+		{"RESHAPE", RESHAPE[[][]int]([]int{1, 2, 3, 4, 5, 6}, []int{3, 2}, nil),
+			[][]int{{1, 4}, {2, 5}, {3, 6}}},
+		{"RESHAPE(PAD)", RESHAPE[[][]int]([]int{1, 2, 3}, []int{2, 3}, []int{0, 9}),
+			[][]int{{1, 3, 9}, {2, 0, 0}}},
+		{"SPREAD(DIM=1)", SPREAD[[][]int]([]int{1, 2}, 1, 3), [][]int{{1, 2}, {1, 2}, {1, 2}}},
+		{"SPREAD(DIM=2)", SPREAD[[][]int]([]int{1, 2}, 2, 3), [][]int{{1, 1, 1}, {2, 2, 2}}},
+		{"SPREAD(7,1,2)", SPREAD[[]int](7, 1, 2), []int{7, 7}},
+		{"PACK(A,L)", PACK[[]int](a, l, nil), []int{4, 5, 3, 6}},
+		{"PACK(A,L,VECTOR)", PACK[[]int](a, l, []int{0, 0, 0, 0, 0, 10}), []int{4, 5, 3, 6, 0, 10}},
+		{"PACK(V,.TRUE.)", PACK[[]int](v, true, nil), []int{1, 2, 3, 4}},
+		{"UNPACK(FIELD=0)", UNPACK[[][]int]([]int{1, 2, 3}, [][]bool{{false, true}, {true, false}}, 0),
+			[][]int{{0, 2}, {1, 0}}},
+		{"UNPACK(FIELD)", UNPACK[[]int]([]int{1}, []bool{false, true}, []int{7, 8}), []int{7, 1}},
+		{"CSHIFT(V,1)", CSHIFT[[]int](v, 1, 1), []int{2, 3, 4, 1}},
+		{"CSHIFT(V,-1)", CSHIFT[[]int](v, -1, 1), []int{4, 1, 2, 3}},
+		{"CSHIFT(A,1,DIM=2)", CSHIFT[[][]int](a, 1, 2), [][]int{{2, 3, 1}, {5, 6, 4}}},
+		{"CSHIFT(A,1,DIM=1)", CSHIFT[[][]int](a, 1, 1), [][]int{{4, 5, 6}, {1, 2, 3}}},
+		{"EOSHIFT(V,1)", EOSHIFT[[]int](v, 1, nil, 1), []int{2, 3, 4, 0}},
+		{"EOSHIFT(V,-2,9)", EOSHIFT[[]int](v, -2, 9, 1), []int{9, 9, 1, 2}},
+		{"EOSHIFT(A,1,DIM=1)", EOSHIFT[[][]int](a, 1, nil, 1), [][]int{{4, 5, 6}, {0, 0, 0}}},
+		{"MERGE(1,2,.TRUE.)", MERGE[int](1, 2, true), 1},
+		{"MERGE(X,Y,.FALSE.)", MERGE[float64](1.5, 2.5, false), 2.5},
+		{"MERGE(X,1,.FALSE.)", MERGE[float64](1.5, 1, false), 1.0},
+		{"MERGE(V,-V,V>2)", MERGE[[]int](v, []int{-1, -2, -3, -4}, []bool{false, false, true, true}),
+			[]int{-1, -2, 3, 4}},
+		{"MERGE(A,0,L)", MERGE[[][]int](a, 0, l), [][]int{{0, 0, 3}, {4, 5, 6}}},
+		{"(/1,2/)", ARRAY(1, 2), []int{1, 2}},
+	}
+	for _, tc := range tcs {
+		if !reflect.DeepEqual(tc.result, tc.expect) {
+			t.Errorf("Not valid result of %s: %v != %v", tc.name, tc.result, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package intrinsic
+
+// Bit manipulation intrinsic functions. Bits are numbered from 0 for
+// the rightmost bit up to BIT_SIZE(i)-1, where bit size is size of
+// type: 64 for int64, 32 for int and int32, 16 for int16. Type int is
+// INTEGER of fortran with size 32 bits. Value is shifted as unsigned
+// bits:
+//
+//	ISHFT(-8, -1)  - 2147483644 as int
+//	ISHFTC(6, -1)  - 3
+
+// BIT_SIZE is amount of bits in value of type
+func BIT_SIZE[T Integer](i T) int {
+	return integerDigits(i) + 1
+}
+
+// integer return value from n rightmost bits, bit n-1 is sign bit
+func integer[T Integer](u uint64, n int) T {
+	return T(int64(u<<uint(64-n)) >> uint(64-n))
+}
+
+// mask return value with n rightmost bits
+func mask(n int) uint64 {
+	if n <= 0 {
+		return 0
+	}
+	if n >= 64 {
+		return ^uint64(0)
+	}
+	return 1<<uint(n) - 1
+}
+
+// IAND is bitwise AND
+func IAND[T Integer](i, j T) T { return i & j }
+
+// IOR is bitwise inclusive OR
+func IOR[T Integer](i, j T) T { return i | j }
+
+// IEOR is bitwise exclusive OR
+func IEOR[T Integer](i, j T) T { return i ^ j }
+
+// NOT is bitwise complement
+func NOT[T Integer](i T) T { return ^i }
+
+// ISHFT is logical shift of value to left for positive shift and to
+// right for negative shift. Vacated bits are zero.
+func ISHFT[T Integer](i T, shift int) T {
+	n := BIT_SIZE(i)
+	if shift >= n || shift <= -n {
+		return 0
+	}
+	u := uint64(i) & mask(n)
+	if shift >= 0 {
+		return integer[T](u<<uint(shift), n)
+	}
+	return integer[T](u>>uint(-shift), n)
+}
+
+// ISHFTC is circular shift of size rightmost bits of value to left for
+// positive shift and to right for negative shift. Other bits are not
+// changed. By default, size is bit size of value.
+func ISHFTC[T Integer](i T, shift int, size ...int) T {
+	n := BIT_SIZE(i)
+	s := n
+	if len(size) > 0 {
+		s = size[0]
+	}
+	if s <= 0 {
+		return i
+	}
+	shift %= s
+	if shift < 0 {
+		shift += s
+	}
+	m := mask(s)
+	u := uint64(i)
+	v := u & m
+	v = (v<<uint(shift) | v>>uint(s-shift)) & m
+	return integer[T](u&^m|v, n)
+}
+
+// IBSET is value with bit pos set to 1
+func IBSET[T Integer](i T, pos int) T {
+	return integer[T](uint64(i)|1<<uint(pos), BIT_SIZE(i))
+}
+
+// IBCLR is value with bit pos set to 0
+func IBCLR[T Integer](i T, pos int) T {
+	return integer[T](uint64(i)&^(1<<uint(pos)), BIT_SIZE(i))
+}
+
+// BTEST is true, if bit pos of value is 1
+func BTEST[T Integer](i T, pos int) bool {
+	return uint64(i)>>uint(pos)&1 == 1
+}
+
+// IBITS is length bits of value from bit pos, right adjusted
+func IBITS[T Integer](i T, pos, length int) T {
+	return integer[T](uint64(i)>>uint(pos)&mask(length), BIT_SIZE(i))
+}
+
+// MVBITS copy length bits of from, started at bit frompos, into to,
+// started at bit topos. Other bits of to are not changed.
+func MVBITS[T Integer](from T, frompos, length int, to *T, topos int) {
+	m := mask(length)
+	v := uint64(from) >> uint(frompos) & m
+	*to = integer[T](uint64(*to)&^(m<<uint(topos))|v<<uint(topos), BIT_SIZE(from))
+}
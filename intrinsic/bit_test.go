@@ -0,0 +1,57 @@
+package intrinsic
+
+import "testing"
+
+func TestBit(t *testing.T) {
+	// results of gfortran for INTEGER, INTEGER*4, INTEGER*2 and
+	// INTEGER*8
+	mv := int32(-1)
+	MVBITS(int32(5), 0, 3, &mv, 4)
+	tcs := []struct {
+		name   string
+		result interface{}
+		expect interface{}
+	}{
+		{"BIT_SIZE(I4)", BIT_SIZE(int32(0)), 32},
+		{"BIT_SIZE(I)", BIT_SIZE(0), 32},
+		{"BIT_SIZE(I8)", BIT_SIZE(int64(0)), 64},
+		{"IAND(12,10)", IAND(12, 10), 8},
+		{"IOR(12,10)", IOR(12, 10), 14},
+		{"IEOR(12,10)", IEOR(12, 10), 6},
+		{"NOT(0)", NOT(int32(0)), int32(-1)},
+		{"ISHFT(1,4)", ISHFT(int32(1), 4), int32(16)},
+		{"ISHFT(-8,-1)", ISHFT(int32(-8), -1), int32(2147483644)},
+		{"ISHFT(-8_2,-1)", ISHFT(int16(-8), -1), int16(32764)},
+		{"ISHFT(1,31)", ISHFT(int32(1), 31), int32(-2147483648)},
+		{"ISHFT(1,32)", ISHFT(int32(1), 32), int32(0)},
+		{"ISHFT(-1,-32)", ISHFT(int32(-1), -32), int32(0)},
+		{"ISHFT(-1_8,-63)", ISHFT(int64(-1), -63), int64(1)},
+		{"ISHFT(-1,-1)", ISHFT(-1, -1), 2147483647},
+		{"ISHFT(1,31) INTEGER", ISHFT(1, 31), -2147483648},
+		{"ISHFTC(6,-1)", ISHFTC(int32(6), -1), int32(3)},
+		{"ISHFTC(1,-1)", ISHFTC(int32(1), -1), int32(-2147483648)},
+		{"ISHFTC(1,-1) INTEGER", ISHFTC(1, -1), -2147483648},
+		{"ISHFTC(-2,-1)", ISHFTC(-2, -1), 2147483647},
+		{"ISHFTC(6,1,3)", ISHFTC(int32(6), 1, 3), int32(5)},
+		{"ISHFTC(14,-1,3)", ISHFTC(int32(14), -1, 3), int32(11)},
+		{"ISHFTC(-1,5)", ISHFTC(int32(-1), 5), int32(-1)},
+		{"ISHFTC(5,0)", ISHFTC(int32(5), 0), int32(5)},
+		{"IBSET(0,31)", IBSET(int32(0), 31), int32(-2147483648)},
+		{"IBSET(8,1)", IBSET(8, 1), 10},
+		{"IBCLR(-1,0)", IBCLR(int32(-1), 0), int32(-2)},
+		{"BTEST(5,2)", BTEST(5, 2), true},
+		{"BTEST(5,1)", BTEST(5, 1), false},
+		{"BTEST(-1,31)", BTEST(int32(-1), 31), true},
+		{"IBITS(14,1,3)", IBITS(14, 1, 3), 7},
+		{"IBITS(-1,28,4)", IBITS(int32(-1), 28, 4), int32(15)},
+		{"IBITS(5,0,0)", IBITS(5, 0, 0), 0},
+		{"IBITS(-1,0,32)", IBITS(-1, 0, 32), -1},
+		{"IBSET(0,31) INTEGER", IBSET(0, 31), -2147483648},
+		{"MVBITS(5,0,3,-1,4)", mv, int32(-33)},
+	}
+	for _, tc := range tcs {
+		if tc.result != tc.expect {
+			t.Errorf("Not valid result of %s: %v != %v", tc.name, tc.result, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package intrinsic
+
+// Character is value of type CHARACTER with fixed length. Value is
+// changed by method Set only, so length of value is not changed.
+// Substring is slice of value:
+//
+//	S(2:4)       - S[1:4]
+//	S(2:4) = 'A' - S[1:4].Set([]byte("A"))
+//
+// Values are compared like in Fortran: shorter value is padded with
+// blanks, so next values are equal:
+//
+//	'ABC' and 'ABC   '
+type Character []byte
+
+// NewCharacter return blank value with length n
+func NewCharacter(n int) *Character {
+	s := make(Character, n)
+	for i := range s {
+		s[i] = ' '
+	}
+	return &s
+}
+
+// NewCharacters return array with size blank values with length n
+func NewCharacters(n, size int) []Character {
+	arr := make([]Character, size)
+	for i := range arr {
+		arr[i] = *NewCharacter(n)
+	}
+	return arr
+}
+
+// Set copy v to s. Value is truncated, if v is longer s, or padded
+// with blanks, if v is shorter s.
+func (s Character) Set(v []byte) {
+	n := copy(s, v)
+	for ; n < len(s); n++ {
+		s[n] = ' '
+	}
+}
+
+func (s Character) String() string {
+	return string(s)
+}
+
+// CONCAT is concatenation of values: a // b
+func CONCAT(a ...[]byte) Character {
+	var n int
+	for i := range a {
+		n += len(a[i])
+	}
+	s := make(Character, 0, n)
+	for i := range a {
+		s = append(s, a[i]...)
+	}
+	return s
+}
+
+// Character intrinsic functions. Type CHARACTER*1 is byte in functions
+// ICHAR, IACHAR, CHAR, ACHAR. Position of characters is started from
+// 1, position 0 is not found.
+
+// ICHAR is code of character
+func ICHAR(c byte) int { return int(c) }
+
+// IACHAR is ASCII code of character
+func IACHAR(c byte) int { return int(c) }
+
+// CHAR is character with code
+func CHAR(i int) byte { return byte(i) }
+
+// ACHAR is character with ASCII code
+func ACHAR(i int) byte { return byte(i) }
+
+// COMPARE return -1, 0, +1 for a less, equal or greater b. Shorter
+// value is padded with blanks. Relational operators for CHARACTER
+// values are changed by translator:
+//
+//	A .LT. B  - COMPARE(A, B) < 0
+func COMPARE(a, b []byte) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		ca, cb := byte(' '), byte(' ')
+		if i < len(a) {
+			ca = a[i]
+		}
+		if i < len(b) {
+			cb = b[i]
+		}
+		if ca < cb {
+			return -1
+		}
+		if ca > cb {
+			return 1
+		}
+	}
+	return 0
+}
+
+// LGE is true, if a is greater or equal b in ASCII collating sequence
+func LGE(a, b []byte) bool { return COMPARE(a, b) >= 0 }
+
+// LGT is true, if a is greater b in ASCII collating sequence
+func LGT(a, b []byte) bool { return COMPARE(a, b) > 0 }
+
+// LLE is true, if a is less or equal b in ASCII collating sequence
+func LLE(a, b []byte) bool { return COMPARE(a, b) <= 0 }
+
+// LLT is true, if a is less b in ASCII collating sequence
+func LLT(a, b []byte) bool { return COMPARE(a, b) < 0 }
+
+// INDEX is position of first substring sub in s. If back is true,
+// then position of last substring.
+func INDEX(s, sub []byte, back ...bool) int {
+	if len(sub) > len(s) {
+		return 0
+	}
+	if len(back) > 0 && back[0] {
+		for i := len(s) - len(sub); i >= 0; i-- {
+			if string(s[i:i+len(sub)]) == string(sub) {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if string(s[i:i+len(sub)]) == string(sub) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LEN_TRIM is length of s without trailing blanks
+func LEN_TRIM(s []byte) int {
+	n := len(s)
+	for n > 0 && s[n-1] == ' ' {
+		n--
+	}
+	return n
+}
+
+// TRIM is s without trailing blanks
+func TRIM(s []byte) []byte {
+	return s[:LEN_TRIM(s)]
+}
+
+// ADJUSTL is s with leading blanks moved to end
+func ADJUSTL(s []byte) []byte {
+	i := 0
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	r := make([]byte, len(s))
+	n := copy(r, s[i:])
+	for ; n < len(r); n++ {
+		r[n] = ' '
+	}
+	return r
+}
+
+// ADJUSTR is s with trailing blanks moved to begin
+func ADJUSTR(s []byte) []byte {
+	n := LEN_TRIM(s)
+	r := make([]byte, len(s))
+	for i := 0; i < len(s)-n; i++ {
+		r[i] = ' '
+	}
+	copy(r[len(s)-n:], s[:n])
+	return r
+}
+
+// REPEAT is n copies of s
+func REPEAT(s []byte, n int) []byte {
+	r := make([]byte, 0, len(s)*n)
+	for i := 0; i < n; i++ {
+		r = append(r, s...)
+	}
+	return r
+}
+
+// SCAN is position of first character of s in set. If back is true,
+// then position of last character.
+func SCAN(s, set []byte, back ...bool) int {
+	return search(s, set, true, len(back) > 0 && back[0])
+}
+
+// VERIFY is position of first character of s not in set. If back is
+// true, then position of last character.
+func VERIFY(s, set []byte, back ...bool) int {
+	return search(s, set, false, len(back) > 0 && back[0])
+}
+
+func search(s, set []byte, in, back bool) int {
+	found := func(c byte) bool {
+		for _, v := range set {
+			if v == c {
+				return in
+			}
+		}
+		return !in
+	}
+	if back {
+		for i := len(s) - 1; i >= 0; i-- {
+			if found(s[i]) {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	for i := range s {
+		if found(s[i]) {
+			return i + 1
+		}
+	}
+	return 0
+}
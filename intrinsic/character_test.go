@@ -0,0 +1,75 @@
+package intrinsic
+
+import "testing"
+
+func TestCharacter(t *testing.T) {
+	// results of gfortran
+	tcs := []struct {
+		name   string
+		result interface{}
+		expect interface{}
+	}{
+		{"ICHAR('A')", ICHAR('A'), 65},
+		{"CHAR(97)", CHAR(97), byte('a')},
+		{"IACHAR(' ')", IACHAR(' '), 32},
+		{"ACHAR(90)", ACHAR(90), byte('Z')},
+		{"'ABC' .EQ. 'ABC  '", COMPARE([]byte("ABC"), []byte("ABC  ")), 0},
+		{"'AB' .LT. 'AB!'", COMPARE([]byte("AB"), []byte("AB!")), -1},
+		{"'AB' .GT. 'AB\\t'", COMPARE([]byte("AB"), []byte("AB\t")), 1},
+		{"'' .EQ. ' '", COMPARE(nil, []byte(" ")), 0},
+		{"LGE('B','A')", LGE([]byte("B"), []byte("A")), true},
+		{"LGT('A','A ')", LGT([]byte("A"), []byte("A ")), false},
+		{"LLE('A ','A')", LLE([]byte("A "), []byte("A")), true},
+		{"LLT('a','B')", LLT([]byte("a"), []byte("B")), false},
+		{"INDEX('ABCABC','BC')", INDEX([]byte("ABCABC"), []byte("BC")), 2},
+		{"INDEX('ABCABC','BC',.TRUE.)", INDEX([]byte("ABCABC"), []byte("BC"), true), 5},
+		{"INDEX('ABC','X')", INDEX([]byte("ABC"), []byte("X")), 0},
+		{"INDEX('ABC','')", INDEX([]byte("ABC"), nil), 1},
+		{"INDEX('AB','ABC')", INDEX([]byte("AB"), []byte("ABC")), 0},
+		{"LEN_TRIM(' AB  ')", LEN_TRIM([]byte(" AB  ")), 3},
+		{"LEN_TRIM('   ')", LEN_TRIM([]byte("   ")), 0},
+		{"TRIM(' AB  ')", string(TRIM([]byte(" AB  "))), " AB"},
+		{"ADJUSTL('  AB ')", string(ADJUSTL([]byte("  AB "))), "AB   "},
+		{"ADJUSTR(' AB  ')", string(ADJUSTR([]byte(" AB  "))), "   AB"},
+		{"REPEAT('AB',3)", string(REPEAT([]byte("AB"), 3)), "ABABAB"},
+		{"REPEAT('AB',0)", string(REPEAT([]byte("AB"), 0)), ""},
+		{"SCAN('FORTRAN','TR')", SCAN([]byte("FORTRAN"), []byte("TR")), 3},
+		{"SCAN('FORTRAN','TR',.TRUE.)", SCAN([]byte("FORTRAN"), []byte("TR"), true), 5},
+		{"SCAN('FORTRAN','XY')", SCAN([]byte("FORTRAN"), []byte("XY")), 0},
+		{"VERIFY('ABBA','A')", VERIFY([]byte("ABBA"), []byte("A")), 2},
+		{"VERIFY('ABBA','A',.TRUE.)", VERIFY([]byte("ABBA"), []byte("A"), true), 3},
+		{"VERIFY('ABBA','AB')", VERIFY([]byte("ABBA"), []byte("AB")), 0},
+	}
+	for _, tc := range tcs {
+		if tc.result != tc.expect {
+			t.Errorf("Not valid result of %s: %v != %v", tc.name, tc.result, tc.expect)
+		}
+	}
+}
+
+func TestCharacterType(t *testing.T) {
+	s := NewCharacter(5)
+	if string(*s) != "     " {
+		t.Errorf("Not valid blank value: `%s`", *s)
+	}
+	s.Set([]byte("AB"))
+	if s.String() != "AB   " {
+		t.Errorf("Not valid padded value: `%s`", *s)
+	}
+	s.Set([]byte("ABCDEFG"))
+	if s.String() != "ABCDE" {
+		t.Errorf("Not valid truncated value: `%s`", *s)
+	}
+	(*s)[1:3].Set([]byte("X"))
+	if s.String() != "AX DE" {
+		t.Errorf("Not valid substring value: `%s`", *s)
+	}
+	if c := CONCAT(*s, []byte("12"), nil); string(c) != "AX DE12" {
+		t.Errorf("Not valid concatenation: `%s`", c)
+	}
+	arr := NewCharacters(2, 3)
+	arr[1].Set([]byte("QWE"))
+	if len(arr) != 3 || string(arr[0]) != "  " || string(arr[1]) != "QW" {
+		t.Errorf("Not valid array: %q", arr)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly || solaris || aix || illumos)
+
+package intrinsic
+
+import "time"
+
+// cpuTime return time from start of program as user processor time,
+// because processor time is not available
+func cpuTime() (user, system float64) {
+	return time.Since(start).Seconds(), 0
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly || solaris || aix || illumos
+
+package intrinsic
+
+import "syscall"
+
+// cpuTime return user and system processor time of program in seconds
+func cpuTime() (user, system float64) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, 0
+	}
+	seconds := func(tv syscall.Timeval) float64 {
+		return float64(tv.Sec) + float64(tv.Usec)/1e6
+	}
+	return seconds(ru.Utime), seconds(ru.Stime)
+}
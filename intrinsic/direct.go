@@ -0,0 +1,105 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// status code of output after end of record in direct access file,
+// same as in gfortran
+const iostatDirectEOR = 5015
+
+// Record is record of unit connected for direct access. Record is used
+// as unit of input/output statements with specifier REC.
+//
+// Example:
+//
+//	WRITE(Record{Unit: 10, REC: 3}, nil, []byte("(I5)"), N)
+//	READU(Record{Unit: 11, REC: K}, nil, A)
+type Record struct {
+	Unit int
+	REC  int
+}
+
+// connection return connection of unit for data transfer with form
+// FORMATTED or UNFORMATTED and number of record for direct access.
+// Unit is number of unit or Record. Number of record is zero for
+// sequential access.
+func (us *Units) connection(unit interface{}, form string) (u *unit, rec int, err error) {
+	switch v := unit.(type) {
+	case int:
+		u, err = us.connected(v, form)
+	case Record:
+		if v.REC < 1 {
+			return nil, 0, ioError{code: iostatBadOption, err: fmt.Errorf(
+				"Record number must be positive: %d", v.REC)}
+		}
+		u, err = us.connected(v.Unit, form)
+		rec = v.REC
+	default:
+		return nil, 0, fmt.Errorf("Not valid unit of type %T", unit)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	switch {
+	case u.access == "DIRECT" && rec == 0:
+		return nil, 0, ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"REC is required for direct access")}
+	case u.access != "DIRECT" && rec != 0:
+		return nil, 0, ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"REC is not allowed for sequential access")}
+	}
+	return
+}
+
+// lockedConnection return connection of unit like connection. Lock of
+// table is held only for finding of connection, returned unit is locked
+// for data transfer. So transfer of unit does not block other units,
+// for example, when writer of pipe is other unit.
+func (us *Units) lockedConnection(unit interface{}, form string) (*unit, int, error) {
+	us.mu.Lock()
+	u, rec, err := us.connection(unit, form)
+	us.mu.Unlock()
+	if err != nil {
+		return nil, 0, err
+	}
+	u.mu.Lock()
+	return u, rec, nil
+}
+
+// writeDirect write records in file from record rec. Records are
+// padded by pad to length RECL.
+func (u *unit) writeDirect(rec int, records [][]byte, pad byte) error {
+	for i, record := range records {
+		if len(record) > u.recl {
+			return ioError{code: iostatDirectEOR, err: fmt.Errorf(
+				"Write exceeds length of DIRECT access record: %d > %d",
+				len(record), u.recl)}
+		}
+		b := append(record, bytes.Repeat([]byte{pad}, u.recl-len(record))...)
+		if _, err := u.writeAt(b, int64(rec-1+i)*int64(u.recl)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readDirect return function for reading records of file from
+// record rec. Record that is not in file is error.
+func (u *unit) readDirect(rec int) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		b := make([]byte, u.recl)
+		n, err := u.readAt(b, int64(rec-1)*int64(u.recl))
+		if n < len(b) {
+			if err == io.EOF {
+				err = ioError{code: iostatBadOption, err: fmt.Errorf(
+					"Non-existing record number %d", rec)}
+			}
+			return nil, err
+		}
+		rec++
+		return b, nil
+	}
+}
@@ -0,0 +1,69 @@
+package intrinsic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := []byte(filepath.Join(dir, "direct.txt"))
+	var iostat int
+
+	// formatted
+	OPEN(10, nil, OpenSpec{FILE: file, ACCESS: []byte("DIRECT"), FORM: []byte("FORMATTED"), RECL: 6})
+	WRITE(Record{Unit: 10, REC: 3}, nil, []byte("(I6)"), 3)
+	WRITE(Record{Unit: 10, REC: 1}, nil, []byte("(I6/A)"), 1, []byte("two"))
+	var i, j int
+	s := []byte("   ")
+	READ(Record{Unit: 10, REC: 3}, nil, []byte("(I6)"), &i)
+	READ(Record{Unit: 10, REC: 1}, nil, []byte("(I6/A)"), &j, &s)
+	if i != 3 || j != 1 || string(s) != "two" {
+		t.Errorf("Not valid values: %d %d %q", i, j, s)
+	}
+	if b, _ := ioutil.ReadFile(string(file)); string(b) != "     1two        3" {
+		t.Errorf("Not valid content: %q", b)
+	}
+	READ(Record{Unit: 10, REC: 4}, &iostat, []byte("(I6)"), &i)
+	if iostat != iostatBadOption {
+		t.Errorf("Not valid status for missing record: %d", iostat)
+	}
+	READ(10, &iostat, []byte("(I6)"), &i)
+	if iostat != iostatOptionConflict {
+		t.Errorf("Not valid status without REC: %d", iostat)
+	}
+	WRITE(Record{Unit: 10, REC: 1}, &iostat, []byte("(I7)"), 1)
+	if iostat != iostatDirectEOR {
+		t.Errorf("Not valid status for record longer RECL: %d", iostat)
+	}
+	CLOSE(10, nil, []byte("DELETE"))
+
+	// unformatted
+	OPEN(11, nil, OpenSpec{FILE: file, ACCESS: []byte("DIRECT"), RECL: 16})
+	a := []float64{1.5, 2.5}
+	WRITEU(Record{Unit: 11, REC: 2}, nil, a)
+	WRITEU(Record{Unit: 11, REC: 1}, nil, &i)
+	a[0], a[1] = 0, 0
+	READU(Record{Unit: 11, REC: 2}, nil, a)
+	if a[0] != 1.5 || a[1] != 2.5 {
+		t.Errorf("Not valid values: %v", a)
+	}
+	if st, _ := os.Stat(string(file)); st.Size() != 32 {
+		t.Errorf("Not valid size of file: %d", st.Size())
+	}
+	READU(Record{Unit: 11, REC: 1}, &iostat, a, &i)
+	if iostat != iostatShortRecord {
+		t.Errorf("Not valid status for short record: %d", iostat)
+	}
+	READU(Record{Unit: 11, REC: 0}, &iostat, &i)
+	if iostat != iostatBadOption {
+		t.Errorf("Not valid status for zero record: %d", iostat)
+	}
+	CLOSE(11, nil, []byte("DELETE"))
+}
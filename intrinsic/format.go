@@ -0,0 +1,935 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// formatItem is edit descriptor of FORMAT.
+//
+// Kinds of items:
+//
+//	'I', 'B', 'O', 'Z', 'F', 'E', 'D', 'G', 'L', 'A' - data edit descriptors
+//	'(' - group of items
+//	'\'' - character string or Hollerith constant
+//	'X', 'T', '/', ':', 'P', 'S', 'N' (BN, BZ), '$'
+type formatItem struct {
+	kind byte
+	// sub is variant of descriptor:
+	//	ES, EN     : 'S', 'N'
+	//	TL, TR     : 'L', 'R'
+	//	SP, SS     : 'P', 'S'
+	//	BN, BZ     : 'N', 'Z'
+	sub    byte
+	repeat int
+	// w, d, e are width, digits and exponent digits of descriptor.
+	// Value -1 is used for absent value.
+	// For 'X', 'T' - amount of positions, for 'P' - scale factor.
+	w, d, e int
+	text    []byte
+	group   []formatItem
+}
+
+// cache of parsed formats
+var formats = struct {
+	sync.Mutex
+	m map[string][]formatItem
+}{m: map[string][]formatItem{}}
+
+// parseFormat parse FORMAT specification, for example:
+//
+//	(' ** On entry to ', A, ' parameter number ', I2)
+//	(1X, 3(1PE12.4, :, ','))
+func parseFormat(b []byte) ([]formatItem, error) {
+	formats.Lock()
+	defer formats.Unlock()
+	if list, ok := formats.m[string(b)]; ok {
+		return list, nil
+	}
+	p := formatParser{b: b}
+	p.skip()
+	if !p.next('(') {
+		return nil, p.errorf("expected '('")
+	}
+	list, err := p.group()
+	if err != nil {
+		return nil, err
+	}
+	p.skip()
+	if p.pos != len(p.b) {
+		return nil, p.errorf("unexpected symbols after ')'")
+	}
+	formats.m[string(b)] = list
+	return list, nil
+}
+
+type formatParser struct {
+	b   []byte
+	pos int
+}
+
+func (p *formatParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("format `%s` at position %d: %s",
+		p.b, p.pos+1, fmt.Sprintf(format, a...))
+}
+
+// skip spaces
+func (p *formatParser) skip() {
+	for p.pos < len(p.b) && (p.b[p.pos] == ' ' || p.b[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// peek return next symbol in upper case or 0 at the end
+func (p *formatParser) peek() byte {
+	p.skip()
+	if p.pos >= len(p.b) {
+		return 0
+	}
+	c := p.b[p.pos]
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return c
+}
+
+// next skip symbol c, if it is next
+func (p *formatParser) next(c byte) bool {
+	if p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// number parse unsigned integer, return -1 if it is absent
+func (p *formatParser) number() int {
+	n := -1
+	for c := p.peek(); '0' <= c && c <= '9'; c = p.peek() {
+		if n < 0 {
+			n = 0
+		}
+		n = n*10 + int(c-'0')
+		p.pos++
+	}
+	return n
+}
+
+// group parse items until ')'
+func (p *formatParser) group() (list []formatItem, err error) {
+	for {
+		c := p.peek()
+		switch c {
+		case 0:
+			return nil, p.errorf("expected ')'")
+		case ')':
+			p.pos++
+			return
+		case ',':
+			p.pos++
+			continue
+		}
+
+		// repeat count or scale factor
+		negative := false
+		if c == '-' || c == '+' {
+			negative = c == '-'
+			p.pos++
+		}
+		n := p.number()
+		if negative {
+			if n < 0 {
+				return nil, p.errorf("expected number after sign")
+			}
+			n = -n
+		}
+
+		c = p.peek()
+		if c == 0 {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		it := formatItem{kind: c, repeat: 1, w: -1, d: -1, e: -1}
+		if n > 0 {
+			it.repeat = n
+		}
+		switch c {
+		case '(':
+			if it.group, err = p.group(); err != nil {
+				return
+			}
+		case '\'', '"':
+			it.kind = '\''
+			for {
+				if p.pos >= len(p.b) {
+					return nil, p.errorf("unterminated character constant")
+				}
+				if p.b[p.pos] == c {
+					p.pos++
+					if p.pos < len(p.b) && p.b[p.pos] == c {
+						it.text = append(it.text, c)
+						p.pos++
+						continue
+					}
+					break
+				}
+				it.text = append(it.text, p.b[p.pos])
+				p.pos++
+			}
+		case 'H':
+			// Hollerith constant
+			if n <= 0 || p.pos+n > len(p.b) {
+				return nil, p.errorf("wrong Hollerith constant")
+			}
+			it.kind, it.repeat = '\'', 1
+			it.text = append([]byte{}, p.b[p.pos:p.pos+n]...)
+			p.pos += n
+		case 'P':
+			if n < 0 && !negative {
+				return nil, p.errorf("expected scale factor before P")
+			}
+			it.w, it.repeat = n, 1
+		case 'X':
+			it.w, it.repeat = it.repeat, 1
+		case '/':
+		case ':', '$', '\\':
+			if c == '\\' {
+				it.kind = '$'
+			}
+		case 'T':
+			if p.next('L') {
+				it.sub = 'L'
+			} else if p.next('R') {
+				it.sub = 'R'
+			}
+			if it.w = p.number(); it.w < 0 {
+				return nil, p.errorf("expected position after T")
+			}
+		case 'S':
+			if p.next('P') {
+				it.sub = 'P'
+			} else if p.next('S') {
+				it.sub = 'S'
+			}
+		case 'B':
+			if p.next('N') {
+				it.kind, it.sub = 'N', 'N'
+				break
+			} else if p.next('Z') {
+				it.kind, it.sub = 'N', 'Z'
+				break
+			}
+			p.descriptor(&it)
+		case 'E':
+			if p.next('S') {
+				it.sub = 'S'
+			} else if p.next('N') {
+				it.sub = 'N'
+			}
+			p.descriptor(&it)
+		case 'I', 'O', 'Z', 'F', 'D', 'G', 'L', 'A':
+			p.descriptor(&it)
+		default:
+			p.pos--
+			return nil, p.errorf("not supported edit descriptor %c", c)
+		}
+		list = append(list, it)
+	}
+}
+
+// descriptor parse w.dEe of data edit descriptor
+func (p *formatParser) descriptor(it *formatItem) {
+	it.w = p.number()
+	if it.w < 0 {
+		return
+	}
+	if p.next('.') {
+		it.d = p.number()
+		if p.peek() == 'E' {
+			p.pos++
+			it.e = p.number()
+		}
+	}
+}
+
+// formatter is state of formatted transfer
+type formatter struct {
+	items []interface{}
+	// current record and position in it
+	record []byte
+	pos    int
+	// scale factor
+	scale int
+	// sign control: '+' for SP
+	sign byte
+	// blank control: 'Z' for BZ
+	blank byte
+	// amount of transferred items
+	transferred int
+	records     [][]byte
+	// advance is false if format have '$'
+	advance bool
+}
+
+// formatWrite write items by format in records.
+// Result advance is false, if last record must not be finished.
+func formatWrite(format []byte, a []interface{}) (records [][]byte, advance bool, err error) {
+	list, err := parseFormat(format)
+	if err != nil {
+		return nil, true, err
+	}
+	f := formatter{items: flatten(a), advance: true}
+	if err = f.write(list); err != nil {
+		return nil, true, err
+	}
+	return f.records, f.advance, nil
+}
+
+func (f *formatter) write(list []formatItem) error {
+	revert := revertPosition(list)
+	for pass := list; ; pass = list[revert:] {
+		transferred := f.transferred
+		stop, err := f.exec(pass)
+		if err != nil {
+			return err
+		}
+		if stop || len(f.items) == 0 {
+			f.endRecord()
+			return nil
+		}
+		if transferred == f.transferred {
+			return fmt.Errorf("format without data edit descriptors for %d items",
+				len(f.items))
+		}
+		f.endRecord()
+	}
+}
+
+// revertPosition return position of format item for format control
+// reversion: last group of first level or begin of format
+func revertPosition(list []formatItem) (revert int) {
+	for i := range list {
+		if list[i].kind == '(' {
+			revert = i
+		}
+	}
+	return
+}
+
+// exec execute items of format. Result stop is true, if format
+// control is terminated because items are finished.
+func (f *formatter) exec(list []formatItem) (stop bool, err error) {
+	for _, it := range list {
+		switch it.kind {
+		case '(':
+			for r := 0; r < it.repeat; r++ {
+				if stop, err = f.exec(it.group); stop || err != nil {
+					return
+				}
+			}
+		case '\'':
+			f.put(it.text)
+		case 'X':
+			f.pos += it.w
+		case 'T':
+			switch it.sub {
+			case 'L':
+				f.pos -= it.w
+				if f.pos < 0 {
+					f.pos = 0
+				}
+			case 'R':
+				f.pos += it.w
+			default:
+				f.pos = it.w - 1
+				if f.pos < 0 {
+					f.pos = 0
+				}
+			}
+		case '/':
+			for r := 0; r < it.repeat; r++ {
+				f.endRecord()
+			}
+		case ':':
+			if len(f.items) == 0 {
+				return true, nil
+			}
+		case 'P':
+			f.scale = it.w
+		case 'S':
+			f.sign = 0
+			if it.sub == 'P' {
+				f.sign = '+'
+			}
+		case 'N':
+			f.blank = it.sub
+		case '$':
+			f.advance = false
+		default:
+			for r := 0; r < it.repeat; r++ {
+				if len(f.items) == 0 {
+					return true, nil
+				}
+				v := f.items[0]
+				f.items = f.items[1:]
+				f.transferred++
+				b, err := f.edit(it, v)
+				if err != nil {
+					return false, err
+				}
+				f.put(b)
+			}
+		}
+	}
+	return false, nil
+}
+
+// put write bytes in current position of record
+func (f *formatter) put(b []byte) {
+	for len(f.record) < f.pos {
+		f.record = append(f.record, ' ')
+	}
+	n := copy(f.record[f.pos:], b)
+	f.record = append(f.record, b[n:]...)
+	f.pos += len(b)
+}
+
+func (f *formatter) endRecord() {
+	f.records = append(f.records, f.record)
+	f.record = nil
+	f.pos = 0
+}
+
+// edit return external representation of value v by data edit descriptor
+func (f *formatter) edit(it formatItem, v interface{}) ([]byte, error) {
+	switch it.kind {
+	case 'I', 'B', 'O', 'Z':
+		i, bits, ok := toInteger(v)
+		if !ok {
+			break
+		}
+		w := it.w
+		if w < 0 {
+			w = defaultIntegerWidth(bits)
+		}
+		if it.kind == 'I' {
+			return editI(i, w, it.d, f.sign), nil
+		}
+		return editBOZ(i, bits, it.kind, w, it.d), nil
+
+	case 'F', 'E', 'D', 'G':
+		x, bits, ok := toReal(v)
+		if !ok {
+			if it.kind != 'G' {
+				break
+			}
+			// G editing of not real values
+			switch v.(type) {
+			case bool:
+				it.kind = 'L'
+			case []byte, string:
+				it.kind = 'A'
+			default:
+				it.kind, it.d = 'I', -1
+			}
+			return f.edit(it, v)
+		}
+		w, d, e := it.w, it.d, it.e
+		if w < 0 {
+			w, d, e = defaultRealWidth(bits)
+		}
+		if d < 0 {
+			d = 0
+		}
+		switch it.kind {
+		case 'F':
+			return editF(x, w, d, f.scale, f.sign), nil
+		case 'E':
+			return editE(x, w, d, e, f.scale, 'E', it.sub, f.sign), nil
+		case 'D':
+			return editE(x, w, d, e, f.scale, 'D', 0, f.sign), nil
+		}
+		return editG(x, w, d, e, f.scale, f.sign), nil
+
+	case 'L':
+		b, ok := v.(bool)
+		if !ok {
+			break
+		}
+		return editL(b, it.w), nil
+
+	case 'A':
+		switch s := v.(type) {
+		case []byte:
+			return editA(s, it.w), nil
+		case string:
+			return editA([]byte(s), it.w), nil
+		}
+	}
+	return nil, fmt.Errorf("Expected %s for item %d in formatted transfer, got %s",
+		expectedType(it.kind), f.transferred, typeName(v))
+}
+
+func expectedType(kind byte) string {
+	switch kind {
+	case 'I', 'B', 'O', 'Z':
+		return "INTEGER"
+	case 'L':
+		return "LOGICAL"
+	case 'A':
+		return "CHARACTER"
+	}
+	return "REAL"
+}
+
+// typeName return name of fortran type for value
+func typeName(v interface{}) string {
+	if _, _, ok := toInteger(v); ok {
+		return "INTEGER"
+	}
+	if _, _, ok := toReal(v); ok {
+		return "REAL"
+	}
+	switch v.(type) {
+	case bool:
+		return "LOGICAL"
+	case []byte, string:
+		return "CHARACTER"
+	case complex64, complex128:
+		return "COMPLEX"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// flatten return list of scalar items. Arrays are transferred in
+// fortran order (first index is changed faster), complex values are
+// transferred as two real values.
+func flatten(a []interface{}) (items []interface{}) {
+	for i := range a {
+		elements(reflect.ValueOf(a[i]), func(v reflect.Value) {
+			switch v.Kind() {
+			case reflect.Complex64:
+				c := v.Complex()
+				items = append(items, float32(real(c)), float32(imag(c)))
+			case reflect.Complex128:
+				c := v.Complex()
+				items = append(items, real(c), imag(c))
+			default:
+				items = append(items, scalar(v))
+			}
+		})
+	}
+	return
+}
+
+// scalar return value of item, characters are returned as []byte.
+// Value of byte is CHARACTER*1 like result of CHAR.
+func scalar(v reflect.Value) interface{} {
+	if isCharacter(v) {
+		return characterBytes(v)
+	}
+	if v.Kind() == reflect.Uint8 {
+		return []byte{byte(v.Uint())}
+	}
+	return v.Interface()
+}
+
+// elements call f for each scalar value of v. Pointers are
+// dereferenced, arrays are walked in fortran order (first index is
+// changed faster). Values of elements are addressable, if v is a
+// pointer or a slice.
+func elements(v reflect.Value, f func(reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			elements(v.Elem(), f)
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		if !isCharacter(v) {
+			break
+		}
+		fallthrough
+	default:
+		f(v)
+		return
+	}
+
+	// dimensions of array
+	var dims []int
+	for e := v; ; e = e.Index(0) {
+		if e.Kind() != reflect.Slice && e.Kind() != reflect.Array || isCharacter(e) {
+			break
+		}
+		dims = append(dims, e.Len())
+		if e.Len() == 0 {
+			return
+		}
+	}
+	index := make([]int, len(dims))
+	for {
+		e := v
+		for _, i := range index {
+			e = e.Index(i)
+		}
+		elements(e, f)
+		// next index, first index is changed faster
+		k := 0
+		for ; k < len(index); k++ {
+			index[k]++
+			if index[k] < dims[k] {
+				break
+			}
+			index[k] = 0
+		}
+		if k == len(index) {
+			return
+		}
+	}
+}
+
+// isCharacter return true for []byte and [N]byte
+func isCharacter(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) &&
+		v.Type().Elem().Kind() == reflect.Uint8
+}
+
+func characterBytes(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b
+}
+
+// toInteger return value of integer and size in bits.
+// Type int is INTEGER of fortran with size 32 bits.
+func toInteger(v interface{}) (i int64, bits int, ok bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), 32, true
+	case int8:
+		return int64(v), 8, true
+	case int16:
+		return int64(v), 16, true
+	case int32:
+		return int64(v), 32, true
+	case int64:
+		return v, 64, true
+	}
+	return 0, 0, false
+}
+
+// toReal return value of real and size in bits
+func toReal(v interface{}) (x float64, bits int, ok bool) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), 32, true
+	case float64:
+		return v, 64, true
+	}
+	return 0, 0, false
+}
+
+// defaultIntegerWidth is width of I edit descriptor without width
+func defaultIntegerWidth(bits int) int {
+	switch bits {
+	case 8:
+		return 7
+	case 16:
+		return 7
+	case 64:
+		return 23
+	}
+	return 12
+}
+
+// defaultRealWidth is width, digits and exponent digits of F, E, D, G
+// edit descriptors without width
+func defaultRealWidth(bits int) (w, d, e int) {
+	if bits == 32 {
+		return 15, 7, 2
+	}
+	return 25, 16, 3
+}
+
+// stars return field filled by asterisks
+func stars(w int) []byte {
+	return bytes.Repeat([]byte{'*'}, w)
+}
+
+// justify return field with value s justified to the right
+func justify(s []byte, w int) []byte {
+	if w <= 0 || len(s) == w {
+		return s
+	}
+	if len(s) > w {
+		return stars(w)
+	}
+	return append(bytes.Repeat([]byte{' '}, w-len(s)), s...)
+}
+
+// signOf return sign of value
+func signOf(negative bool, sign byte) []byte {
+	if negative {
+		return []byte{'-'}
+	}
+	if sign == '+' {
+		return []byte{'+'}
+	}
+	return nil
+}
+
+// editI is Iw and Iw.m editing
+func editI(i int64, w, m int, sign byte) []byte {
+	u := uint64(i)
+	if i < 0 {
+		u = -u
+	}
+	digits := strconv.AppendUint(nil, u, 10)
+	if m == 0 && i == 0 {
+		digits = nil
+	}
+	for len(digits) < m {
+		digits = append([]byte{'0'}, digits...)
+	}
+	return justify(append(signOf(i < 0, sign), digits...), w)
+}
+
+// editBOZ is Bw.m, Ow.m and Zw.m editing
+func editBOZ(i int64, bits int, kind byte, w, m int) []byte {
+	u := uint64(i)
+	if bits < 64 {
+		u &= 1<<uint(bits) - 1
+	}
+	base := 16
+	switch kind {
+	case 'B':
+		base = 2
+	case 'O':
+		base = 8
+	}
+	digits := bytes.ToUpper(strconv.AppendUint(nil, u, base))
+	if m == 0 && u == 0 {
+		digits = nil
+	}
+	for len(digits) < m {
+		digits = append([]byte{'0'}, digits...)
+	}
+	return justify(digits, w)
+}
+
+// editL is Lw editing
+func editL(b bool, w int) []byte {
+	if w < 0 {
+		w = 2
+	}
+	s := []byte{'F'}
+	if b {
+		s[0] = 'T'
+	}
+	return justify(s, w)
+}
+
+// editA is A and Aw editing
+func editA(s []byte, w int) []byte {
+	if w < 0 {
+		return s
+	}
+	if len(s) >= w {
+		return s[:w]
+	}
+	return append(bytes.Repeat([]byte{' '}, w-len(s)), s...)
+}
+
+// editSpecial return representation of NaN and Infinity
+func editSpecial(x float64, w int, sign byte) []byte {
+	if math.IsNaN(x) {
+		return justify([]byte("NaN"), w)
+	}
+	s := signOf(x < 0, sign)
+	if w == 0 || w >= len(s)+8 {
+		return justify(append(s, "Infinity"...), w)
+	}
+	return justify(append(s, "Inf"...), w)
+}
+
+// significant return n significant digits of absolute value of x and
+// decimal exponent e, so that x = 0.digits * 10^e
+func significant(x float64, n int) (digits []byte, e int) {
+	x = math.Abs(x)
+	if x == 0 {
+		return bytes.Repeat([]byte{'0'}, n), 0
+	}
+	s := strconv.AppendFloat(nil, x, 'e', n-1, 64)
+	pos := bytes.IndexByte(s, 'e')
+	e, _ = strconv.Atoi(string(s[pos+1:]))
+	digits = append(digits, s[0])
+	if pos > 1 {
+		digits = append(digits, s[2:pos]...)
+	}
+	return digits, e + 1
+}
+
+// fixed return integer and fraction part of absolute value of
+// x * 10^k rounded to d digits after decimal point
+func fixed(x float64, d, k int) (ip, fp []byte) {
+	x = math.Abs(x)
+	if d+k < 0 {
+		x *= math.Pow10(k)
+		k = 0
+	}
+	s := strconv.AppendFloat(nil, x, 'f', d+k, 64)
+	point := bytes.IndexByte(s, '.')
+	if point < 0 {
+		point = len(s)
+	}
+	digits := append(append([]byte{}, s[:point]...), s[min(point+1, len(s)):]...)
+	point += k
+	for point <= 0 {
+		digits = append([]byte{'0'}, digits...)
+		point++
+	}
+	ip, fp = digits[:point], digits[point:]
+	for len(ip) > 1 && ip[0] == '0' {
+		ip = ip[1:]
+	}
+	return
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// editF is Fw.d editing with scale factor k
+func editF(x float64, w, d, k int, sign byte) []byte {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return editSpecial(x, w, sign)
+	}
+	ip, fp := fixed(x, d, k)
+	s := signOf(math.Signbit(x), sign)
+	if w > 0 && len(s)+len(ip)+1+len(fp) > w && len(fp) > 0 &&
+		len(ip) == 1 && ip[0] == '0' {
+		// leading zero is optional
+		ip = nil
+	}
+	s = append(append(append(s, ip...), '.'), fp...)
+	return justify(s, w)
+}
+
+// editE is Ew.dEe, ESw.dEe, ENw.dEe and Dw.dEe editing with
+// scale factor k. Variant is 0, 'S' for ES, 'N' for EN.
+func editE(x float64, w, d, e, k int, letter, variant, sign byte) []byte {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return editSpecial(x, w, sign)
+	}
+	var ip, fp []byte
+	var exp int
+	switch variant {
+	case 'S':
+		digits, ex := significant(x, d+1)
+		ip, fp, exp = digits[:1], digits[1:], ex-1
+	case 'N':
+		if x == 0 {
+			ip, fp = []byte{'0'}, bytes.Repeat([]byte{'0'}, d)
+			break
+		}
+		digits, ex := significant(x, d+1)
+		for i := 0; i < 2; i++ {
+			exp = ex - 1
+			exp -= ((exp % 3) + 3) % 3
+			var ex2 int
+			digits, ex2 = significant(x, ex-exp+d)
+			if ex2 == ex {
+				break
+			}
+			ex = ex2
+		}
+		ip, fp = digits[:len(digits)-d], digits[len(digits)-d:]
+	default:
+		switch {
+		case k <= -d || k > d+1:
+			// scale factor out of range
+			return stars(w)
+		case k <= 0:
+			digits, ex := significant(x, d+k)
+			ip = []byte{'0'}
+			fp = append(bytes.Repeat([]byte{'0'}, -k), digits...)
+			exp = ex - k
+		default:
+			digits, ex := significant(x, d+1)
+			ip, fp, exp = digits[:k], digits[k:], ex-k
+		}
+	}
+	if x == 0 {
+		exp = 0
+	}
+
+	// exponent
+	ed := strconv.AppendInt(nil, int64(abs(exp)), 10)
+	var es []byte
+	switch {
+	case e > 0:
+		if len(ed) > e {
+			return stars(w)
+		}
+		es = append([]byte{letter}, signOf(exp < 0, '+')...)
+		es = append(es, bytes.Repeat([]byte{'0'}, e-len(ed))...)
+		es = append(es, ed...)
+	case len(ed) <= 2:
+		es = append([]byte{letter}, signOf(exp < 0, '+')...)
+		es = append(es, bytes.Repeat([]byte{'0'}, 2-len(ed))...)
+		es = append(es, ed...)
+	case len(ed) == 3:
+		es = append(signOf(exp < 0, '+'), ed...)
+	default:
+		return stars(w)
+	}
+
+	s := signOf(math.Signbit(x), sign)
+	if w > 0 && len(s)+len(ip)+1+len(fp)+len(es) > w &&
+		len(ip) == 1 && ip[0] == '0' {
+		// leading zero is optional
+		ip = nil
+	}
+	s = append(append(append(append(s, ip...), '.'), fp...), es...)
+	return justify(s, w)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// editG is Gw.dEe editing of real value with scale factor k
+func editG(x float64, w, d, e, k int, sign byte) []byte {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return editSpecial(x, w, sign)
+	}
+	n := 4
+	if e > 0 {
+		n = e + 2
+	}
+	blanks := bytes.Repeat([]byte{' '}, n)
+	if x == 0 {
+		return append(editF(x, w-n, d-1, 0, sign), blanks...)
+	}
+	if _, ex := significant(x, d); 0 <= ex && ex <= d {
+		return append(editF(x, w-n, d-ex, 0, sign), blanks...)
+	}
+	return editE(x, w, d, e, k, 'E', 0, sign)
+}
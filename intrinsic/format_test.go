@@ -0,0 +1,189 @@
+package intrinsic
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestFormatWrite(t *testing.T) {
+	tcs := []struct {
+		format string
+		items  []interface{}
+		out    string
+	}{
+		// integers
+		{"(I5)", []interface{}{42}, "   42"},
+		{"(I5.3)", []interface{}{7}, "  007"},
+		{"(I3.0)", []interface{}{0}, "   "},
+		{"(I2)", []interface{}{123}, "**"},
+		{"(I0)", []interface{}{-123}, "-123"},
+		{"(SP,I4,SS,I4)", []interface{}{5, 5}, "  +5   5"},
+		{"(Z4)", []interface{}{255}, "  FF"},
+		{"(B8.8)", []interface{}{5}, "00000101"},
+		{"(Z8)", []interface{}{-1}, "FFFFFFFF"},
+		{"(O4)", []interface{}{int64(8)}, "  10"},
+
+		// F editing
+		{"(F8.3)", []interface{}{3.14159}, "   3.142"},
+		{"(F5.2)", []interface{}{-0.5}, "-0.50"},
+		{"(F4.2)", []interface{}{-0.5}, "-.50"},
+		{"(F5.1)", []interface{}{-0.01}, " -0.0"},
+		{"(F6.2)", []interface{}{0.0}, "  0.00"},
+		{"(F4.1)", []interface{}{123.45}, "****"},
+		{"(F5.0)", []interface{}{12.7}, "  13."},
+		{"(F0.3)", []interface{}{-2.5}, "-2.500"},
+		{"(1P,F8.3)", []interface{}{1.2345}, "  12.345"},
+		{"(F8.5)", []interface{}{float32(0.1)}, " 0.10000"},
+
+		// E and D editing
+		{"(E12.4)", []interface{}{1234.5678}, "  0.1235E+04"},
+		{"(1PE12.4)", []interface{}{1234.5678}, "  1.2346E+03"},
+		{"(-1PE12.4)", []interface{}{1234.5678}, "  0.0123E+05"},
+		{"(E10.3)", []interface{}{0.0}, " 0.000E+00"},
+		{"(E10.3)", []interface{}{1.0e100}, " 0.100+101"},
+		{"(E9.3)", []interface{}{-1.0e100}, "-.100+101"},
+		{"(E12.3E3)", []interface{}{1.5e-5}, "  0.150E-004"},
+		{"(D12.4)", []interface{}{1.0}, "  0.1000D+01"},
+		{"(ES12.4)", []interface{}{1234.5678}, "  1.2346E+03"},
+		{"(ES10.3)", []interface{}{-0.000123}, "-1.230E-04"},
+		{"(EN12.3)", []interface{}{12345.678}, "  12.346E+03"},
+		{"(EN12.3)", []interface{}{0.0123}, "  12.300E-03"},
+		{"(E7.3)", []interface{}{1.0}, "*******"},
+
+		// G editing
+		{"(G12.4)", []interface{}{3.5}, "   3.500    "},
+		{"(G12.4)", []interface{}{123456.0}, "  0.1235E+06"},
+		{"(G12.4)", []interface{}{0.0}, "   0.000    "},
+		{"(G12.4)", []interface{}{0.05}, "  0.5000E-01"},
+		{"(G12.4)", []interface{}{9999.5}, "  0.1000E+05"},
+		{"(1P,G12.4)", []interface{}{0.05}, "  5.0000E-02"},
+		{"(1P,G12.4)", []interface{}{12.0}, "   12.00    "},
+		{"(G5.2)", []interface{}{12}, "   12"},
+		{"(G3.2)", []interface{}{true}, "  T"},
+		{"(G4.2)", []interface{}{[]byte("ab")}, "  ab"},
+
+		// special values
+		{"(F10.3)", []interface{}{math.Inf(1)}, "  Infinity"},
+		{"(F5.3)", []interface{}{math.Inf(-1)}, " -Inf"},
+		{"(E10.3)", []interface{}{math.NaN()}, "       NaN"},
+
+		// logical and character
+		{"(L3)", []interface{}{true}, "  T"},
+		{"(L1)", []interface{}{false}, "F"},
+		{"(A5)", []interface{}{[]byte("ab")}, "   ab"},
+		{"(A1)", []interface{}{[]byte("abc")}, "a"},
+		{"(A)", []interface{}{"string"}, "string"},
+		{"(A)", []interface{}{[3]byte{'a', 'b', 'c'}}, "abc"},
+		{"(A,A3)", []interface{}{CHAR(66), ACHAR(67)}, "B  C"},
+
+		// control
+		{"('It''s',1X,\"say \"\"Hi\"\"\")", nil, "It's say \"Hi\""},
+		{"(5HHello,3X,A)", []interface{}{"!"}, "Hello   !"},
+		{"(T5,A,TL2,A)", []interface{}{"xy", "z"}, "    zy"},
+		{"(A,TR3,A)", []interface{}{"a", "b"}, "a   b"},
+		{"(A,5X)", []interface{}{"ab"}, "ab"},
+		{"(I2/I2)", []interface{}{1, 2}, " 1\n 2"},
+		{"(I3/)", []interface{}{1}, "  1\n"},
+		{"(I3,I3)", []interface{}{1}, "  1"},
+		{"(I3,' end')", []interface{}{1}, "  1 end"},
+		{"(I3,' and',I3,' end')", []interface{}{1}, "  1 and"},
+		{"(I3,:,' and',I3)", []interface{}{1}, "  1"},
+		{"()", nil, ""},
+		{"(A)", nil, ""},
+
+		// repeat and reversion
+		{"(3I3)", []interface{}{1, 2, 3, 4}, "  1  2  3\n  4"},
+		{"(3(1X,F6.2))", []interface{}{1.0, 2.0, 3.0, 4.0}, "   1.00   2.00   3.00\n   4.00"},
+		{"('x',2(I2,'y'),(I3))", []interface{}{1, 2, 3, 4, 5}, "x 1y 2y  3\n  4\n  5"},
+		{"(1X,2(I2,:,','))", []interface{}{1, 2, 3}, "  1, 2,\n 3"},
+
+		// arrays and complex values
+		{"(4I2)", []interface{}{[][]int{{1, 2}, {3, 4}}}, " 1 3 2 4"},
+		{"(3I2)", []interface{}{&[]int{1, 2, 3}}, " 1 2 3"},
+		{"(2A2)", []interface{}{[][]byte{[]byte("ab"), []byte("cd")}}, "abcd"},
+		{"(2F5.1)", []interface{}{complex(1.5, -2.5)}, "  1.5 -2.5"},
+
+		// LAPACK TESTING
+		{
+			"( ' *** Error code from ', A, ' =', I5, / ' ==> M =', I5, ', N =', I5, ', type ', I2, ', test(', I2, ') =', G12.5 )",
+			[]interface{}{[]byte("DGETRF"), -4, 10, 20, 3, 1, 12.5},
+			" *** Error code from DGETRF =   -4\n ==> M =   10, N =   20, type  3, test( 1) =  12.500    ",
+		},
+		{
+			"( ' M=', I5, ', N=', I5, ', NB=', I4, ', type ', I2, ', test(', I2, ')=', 1P, G12.5 )",
+			[]interface{}{5, 5, 1, 4, 2, 1.23456e-17},
+			" M=    5, N=    5, NB=   1, type  4, test( 2)= 1.23456E-17",
+		},
+		{
+			"( / ' Tests of the DOUBLE PRECISION LAPACK routines ', / ' LAPACK VERSION ', I1, '.', I1, '.', I1, / / ' The following parameter values will be used:' )",
+			[]interface{}{3, 8, 0},
+			"\n Tests of the DOUBLE PRECISION LAPACK routines \n LAPACK VERSION 3.8.0\n\n The following parameter values will be used:",
+		},
+		{
+			"( 4X, A4, ':  ', 10I6, / 11X, 10I6 )",
+			[]interface{}{[]byte("M   "), 0, 1, 2, 3, 5, 10, 50},
+			"    M   :       0     1     2     3     5    10    50",
+		},
+		{
+			"( ' Relative machine ', A, ' is taken to be', D16.6 )",
+			[]interface{}{[]byte("underflow"), 2.2250738585072014e-308},
+			" Relative machine underflow is taken to be    0.222507-307",
+		},
+		{
+			"( 3X, I2, ': norm(  B - A * X ) / ( norm(A) * norm(X) * EPS )' )",
+			[]interface{}{1},
+			"    1: norm(  B - A * X ) / ( norm(A) * norm(X) * EPS )",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			records, advance, err := formatWrite([]byte(tc.format), tc.items)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !advance {
+				t.Errorf("not advance")
+			}
+			out := string(bytes.Join(records, []byte("\n")))
+			if out != tc.out {
+				t.Errorf("Not same:\n%q\n%q", out, tc.out)
+			}
+		})
+	}
+}
+
+func TestFormatWriteFail(t *testing.T) {
+	tcs := []struct {
+		format string
+		items  []interface{}
+	}{
+		{"(I5", []interface{}{1}},
+		{"I5)", []interface{}{1}},
+		{"(I5) x", []interface{}{1}},
+		{"('abc)", nil},
+		{"(K5)", []interface{}{1}},
+		{"('abc')", []interface{}{1}},
+		{"(I5)", []interface{}{1.0}},
+		{"(F5.1)", []interface{}{1}},
+		{"(A)", []interface{}{1}},
+		{"(L1)", []interface{}{[]byte("T")}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			if _, _, err := formatWrite([]byte(tc.format), tc.items); err == nil {
+				t.Errorf("error is empty")
+			}
+		})
+	}
+}
+
+func TestFormatNotAdvance(t *testing.T) {
+	records, advance, err := formatWrite([]byte("('Enter:',$)"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if advance || len(records) != 1 || string(records[0]) != "Enter:" {
+		t.Errorf("Not valid: %q %v", records, advance)
+	}
+}
@@ -0,0 +1,119 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// InquireSpec is specifiers of INQUIRE statement. Specifiers are
+// variables for result, nil values are ignored.
+type InquireSpec struct {
+	EXIST  *bool
+	OPENED *bool
+	NUMBER *int // number of connected unit or -1
+	NAMED  *bool
+	NAME   []byte
+	ACCESS []byte // SEQUENTIAL, DIRECT, UNDEFINED
+	FORM   []byte // FORMATTED, UNFORMATTED, UNDEFINED
+	SIZE   *int   // size of file in bytes or -1
+}
+
+// INQUIRE store properties of unit or file in specifiers. Unit is
+// number of unit or name of file. If iostat is nil, then error is
+// panic.
+//
+// Example:
+//
+//	INQUIRE([]byte("restart.bin"), nil, InquireSpec{EXIST: &EXST})
+//	INQUIRE(IOS, nil, InquireSpec{OPENED: &LOPEN})
+func INQUIRE(unit interface{}, iostat *int, spec InquireSpec) {
+	DefaultUnits.INQUIRE(unit, iostat, spec)
+}
+
+// INQUIRE is function INQUIRE for table of units
+func (us *Units) INQUIRE(unit interface{}, iostat *int, spec InquireSpec) {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	status(iostat, us.inquire(unit, spec))
+}
+
+func (us *Units) inquire(id interface{}, spec InquireSpec) error {
+	var (
+		u      *unit
+		number = -1
+		exist  bool
+		name   string
+	)
+	switch v := id.(type) {
+	case int:
+		number, exist = v, v >= 0
+		u = us.table[v]
+		if u != nil && !u.scratch {
+			name = u.name
+		}
+	case Character:
+		return us.inquire([]byte(v), spec)
+	case []byte:
+		name = string(bytes.TrimSpace(v))
+		st, err := os.Stat(name)
+		exist = err == nil
+		for n, c := range us.table {
+			if !exist {
+				break
+			}
+			if cst, err := c.stat(); err == nil && os.SameFile(st, cst) {
+				number, u = n, c
+				break
+			}
+		}
+	default:
+		return fmt.Errorf("Not valid unit of type %T", id)
+	}
+	if u == nil {
+		number = -1
+	}
+
+	if spec.EXIST != nil {
+		*spec.EXIST = exist
+	}
+	if spec.OPENED != nil {
+		*spec.OPENED = u != nil
+	}
+	if spec.NUMBER != nil {
+		*spec.NUMBER = number
+	}
+	if spec.NAMED != nil {
+		*spec.NAMED = name != ""
+	}
+	inquireCharacter(spec.NAME, name)
+	access, form := "UNDEFINED", "UNDEFINED"
+	if u != nil {
+		access, form = u.access, u.form
+	}
+	inquireCharacter(spec.ACCESS, access)
+	inquireCharacter(spec.FORM, form)
+	if spec.SIZE != nil {
+		*spec.SIZE = -1
+		var st os.FileInfo
+		var err error
+		if u != nil {
+			st, err = u.stat()
+		} else if name != "" {
+			st, err = os.Stat(name)
+		}
+		if st != nil && err == nil && st.Mode().IsRegular() {
+			*spec.SIZE = int(st.Size())
+		}
+	}
+	return nil
+}
+
+// inquireCharacter store value in CHARACTER variable with blank padding
+func inquireCharacter(b []byte, value string) {
+	n := copy(b, value)
+	for i := n; i < len(b); i++ {
+		b[i] = ' '
+	}
+}
@@ -0,0 +1,51 @@
+package intrinsic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInquire(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := []byte(filepath.Join(dir, "a.txt") + "   ")
+	var (
+		exist, opened bool
+		number, size  int
+		name          = make([]byte, len(file)+3)
+		form          = []byte("xxxxxxxxxxx")
+	)
+	spec := InquireSpec{EXIST: &exist, OPENED: &opened, NUMBER: &number,
+		NAME: name, FORM: form, SIZE: &size}
+
+	INQUIRE(file, nil, spec)
+	if exist || opened || number != -1 || size != -1 {
+		t.Errorf("Not valid for not existed file: %v %v %v %v", exist, opened, number, size)
+	}
+
+	OPEN(10, nil, OpenSpec{FILE: file})
+	WRITE(10, nil, []byte("(I3)"), 1)
+	INQUIRE(file, nil, spec)
+	if !exist || !opened || number != 10 || size != 4 {
+		t.Errorf("Not valid for opened file: %v %v %v %v", exist, opened, number, size)
+	}
+	if string(form) != "FORMATTED  " {
+		t.Errorf("Not valid form: %q", form)
+	}
+
+	INQUIRE(10, nil, spec)
+	if !exist || !opened || number != 10 || string(name[:len(file)]) != string(file) {
+		t.Errorf("Not valid for unit: %v %v %v %q", exist, opened, number, name)
+	}
+	CLOSE(10, nil, nil)
+
+	INQUIRE(10, nil, spec)
+	if !exist || opened || number != -1 || string(form) != "UNDEFINED  " {
+		t.Errorf("Not valid for not connected unit: %v %v %v %q", exist, opened, number, form)
+	}
+}
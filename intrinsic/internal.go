@@ -0,0 +1,82 @@
+package intrinsic
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// internal return records of internal file. Unit is internal file, if
+// it is CHARACTER variable or array of CHARACTER. Each element of array
+// is record. Records share memory with unit.
+//
+// Example:
+//
+//	(*STR)   - []byte, one record
+//	(*LINES) - [][]byte, record for each element
+func internal(unit interface{}) (records [][]byte, ok bool) {
+	switch unit.(type) {
+	case int, Record:
+		return nil, false
+	}
+	v := reflect.ValueOf(unit)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	ok = true
+	elements(v, func(e reflect.Value) {
+		if !isCharacter(e) || e.Kind() != reflect.Slice {
+			ok = false
+			return
+		}
+		records = append(records, e.Bytes())
+	})
+	return records, ok
+}
+
+// internalRecords return function for reading records of internal file
+func internalRecords(records [][]byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if len(records) == 0 {
+			return nil, io.EOF
+		}
+		record := records[0]
+		records = records[1:]
+		return record, nil
+	}
+}
+
+// writeInternal is output of items in records of internal file.
+// Written records are padded by blanks.
+func writeInternal(records [][]byte, format []byte, a []interface{}) (err error) {
+	var out [][]byte
+	if format == nil {
+		out = [][]byte{listWrite(a, 0)}
+	} else if out, _, err = formatWrite(format, a); err != nil {
+		return err
+	}
+	return putInternal(records, out)
+}
+
+// putInternal store output records in records of internal file
+func putInternal(records, out [][]byte) error {
+	if len(out) > len(records) {
+		return ioError{code: iostatEnd, err: fmt.Errorf(
+			"End of file: %d records in internal file", len(records))}
+	}
+	for i := range out {
+		if len(out[i]) > len(records[i]) {
+			return ioError{code: iostatEOR, err: fmt.Errorf(
+				"End of record: length of record %d, but output is %q",
+				len(records[i]), out[i])}
+		}
+		n := copy(records[i], out[i])
+		for k := n; k < len(records[i]); k++ {
+			records[i][k] = ' '
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package intrinsic
+
+import (
+	"testing"
+)
+
+func TestInternal(t *testing.T) {
+	// formatted output
+	s := []byte("xxxxxxxx")
+	WRITE(s, nil, []byte("(I5)"), 42)
+	if string(s) != "   42   " {
+		t.Errorf("Not valid record: %q", s)
+	}
+	lines := [][]byte{[]byte("xxxx"), []byte("xxxx"), []byte("xxxx")}
+	WRITE(&lines, nil, []byte("(I2)"), 1, 2)
+	if string(lines[0]) != " 1  " || string(lines[1]) != " 2  " || string(lines[2]) != "xxxx" {
+		t.Errorf("Not valid records: %q", lines)
+	}
+
+	// list-directed output
+	s = []byte("xxxxxxxxxxxxxx")
+	WRITE(&s, nil, nil, 7)
+	if string(s) != "           7  " {
+		t.Errorf("Not valid list-directed record: %q", s)
+	}
+
+	// input
+	var x float64
+	var i, j int
+	READ([]byte("  1.5 2"), nil, nil, &x, &i)
+	if x != 1.5 || i != 2 {
+		t.Errorf("Not valid list-directed input: %v %v", x, i)
+	}
+	READ(lines, nil, []byte("(I2)"), &i, &j)
+	if i != 1 || j != 2 {
+		t.Errorf("Not valid formatted input: %v %v", i, j)
+	}
+
+	// errors
+	var iostat int
+	READ([]byte("  1"), &iostat, []byte("(I3/I3)"), &i, &j)
+	if iostat != iostatEnd {
+		t.Errorf("Not valid status for end of file: %d", iostat)
+	}
+	WRITE(s, &iostat, []byte("(I20)"), 1)
+	if iostat != iostatEOR {
+		t.Errorf("Not valid status for output longer record: %d", iostat)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Output is longer record")
+			}
+		}()
+		WRITE(s, nil, []byte("(I20)"), 1)
+	}()
+}
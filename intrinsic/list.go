@@ -0,0 +1,395 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// listWrite return record of list-directed output in layout of gfortran.
+//
+// Rules:
+//   - record is started by blank;
+//   - items are separated by blank, except adjacent characters;
+//   - integers are right justified in width 4, 6, 11, 20 for size
+//     8, 16, 32, 64 bits;
+//   - reals are written as 1PG16.9E2 for REAL*4 and 1PG25.17E3
+//     for REAL*8 with 1 digit less in E editing;
+//   - complex values are written as (re,im) without blanks and
+//     right justified in width of two reals and 3;
+//   - logical is T or F;
+//   - characters are in delimiters, if delim is not zero.
+//
+// Example:
+//
+//	PRINT *, 'N =', 5, 1.0D0, .TRUE.
+//
+// output:
+//
+//	" N =           5   1.0000000000000000      T"
+func listWrite(a []interface{}, delim byte) (record []byte) {
+	first, character := true, false
+	for i := range a {
+		elements(reflect.ValueOf(a[i]), func(v reflect.Value) {
+			isChar := isCharacter(v) || v.Kind() == reflect.String || v.Kind() == reflect.Uint8
+			if first || !(isChar && character && delim == 0) {
+				record = append(record, ' ')
+			}
+			first, character = false, isChar
+			item := listItem(v)
+			if isChar && delim != 0 {
+				d := []byte{delim}
+				item = append(append(d, bytes.Replace(item, d, []byte{delim, delim}, -1)...), delim)
+			}
+			record = append(record, item...)
+		})
+	}
+	return
+}
+
+// listItem return external representation of item in list-directed output
+func listItem(v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		return editL(v.Bool(), 1)
+	case reflect.String:
+		return []byte(v.String())
+	case reflect.Uint8:
+		// CHARACTER*1 like result of CHAR
+		return []byte{byte(v.Uint())}
+	case reflect.Complex64, reflect.Complex128:
+		bits := 32
+		if v.Kind() == reflect.Complex128 {
+			bits = 64
+		}
+		c := v.Complex()
+		w, _, _ := listRealWidth(bits)
+		s := []byte{'('}
+		s = append(s, bytes.TrimSpace(listReal(real(c), bits))...)
+		s = append(s, ',')
+		s = append(s, bytes.TrimSpace(listReal(imag(c), bits))...)
+		s = append(s, ')')
+		return justify(s, 2*w+3)
+	}
+	if isCharacter(v) {
+		return characterBytes(v)
+	}
+	iv := v.Interface()
+	if i, bits, ok := toInteger(iv); ok {
+		w := 11
+		switch bits {
+		case 8:
+			w = 4
+		case 16:
+			w = 6
+		case 64:
+			w = 20
+		}
+		return editI(i, w, -1, 0)
+	}
+	if x, bits, ok := toReal(iv); ok {
+		return listReal(x, bits)
+	}
+	return []byte(fmt.Sprintf("%v", iv))
+}
+
+// listRealWidth is width, digits and exponent digits of real value
+// in list-directed output
+func listRealWidth(bits int) (w, d, e int) {
+	if bits == 32 {
+		return 16, 9, 2
+	}
+	return 25, 17, 3
+}
+
+// listReal return real value by G editing with scale factor 1
+func listReal(x float64, bits int) []byte {
+	w, d, e := listRealWidth(bits)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return editSpecial(x, w, 0)
+	}
+	if _, ex := significant(x, d); x != 0 && (ex < 0 || d < ex) {
+		return editE(x, w, d-1, e, 1, 'E', 0, 0)
+	}
+	return editG(x, w, d, e, 0, 0)
+}
+
+// listReader is reader of values in list-directed input
+type listReader struct {
+	// next return next record
+	next   func() ([]byte, error)
+	record []byte
+	pos    int
+	// amount of repeated values and value
+	repeat int
+	value  []byte
+	// slash is true, if input is terminated by slash
+	slash bool
+}
+
+// listRead read items by list-directed input. Function next return
+// next record of input.
+//
+// Rules:
+//   - values are separated by blanks, comma or end of record;
+//   - r*c is r repeated values c, r* is r null values;
+//   - null value does not change item;
+//   - slash terminates input, other items are not changed;
+//   - complex value is (re,im);
+//   - characters are in quotes or without quotes, if value have not
+//     blanks, comma or slash;
+//   - rest of last record is skipped.
+//
+// Example of input:
+//
+//	3*1.5, ,'it''s' (1.0,2D0) .TRUE. /
+func listRead(next func() ([]byte, error), a []interface{}) error {
+	var targets []reflect.Value
+	for i := range a {
+		elements(reflect.ValueOf(a[i]), func(v reflect.Value) {
+			targets = append(targets, v)
+		})
+	}
+	l := listReader{next: next}
+	if len(targets) == 0 {
+		_, err := next()
+		return err
+	}
+	for i, t := range targets {
+		if !t.CanSet() && !(isCharacter(t) && t.Kind() == reflect.Slice) {
+			return fmt.Errorf("item %d in list input is not variable", i+1)
+		}
+		value, null, err := l.item()
+		if err != nil {
+			return err
+		}
+		if l.slash {
+			break
+		}
+		if null {
+			continue
+		}
+		if err := setValue(t, value); err != nil {
+			return fmt.Errorf("%v for item %d in list input", err, i+1)
+		}
+	}
+	return nil
+}
+
+// item return next value of input. Value is null for null values and
+// after slash.
+func (l *listReader) item() (value []byte, null bool, err error) {
+	if l.repeat > 0 {
+		l.repeat--
+		return l.value, l.value == nil, nil
+	}
+	if l.slash {
+		return nil, true, nil
+	}
+
+	// skip blanks and ends of records
+	for {
+		for l.pos < len(l.record) && isBlank(l.record[l.pos]) {
+			l.pos++
+		}
+		if l.pos < len(l.record) {
+			break
+		}
+		if l.record, err = l.next(); err != nil {
+			return nil, true, err
+		}
+		l.pos = 0
+	}
+
+	switch l.record[l.pos] {
+	case ',':
+		l.pos++
+		return nil, true, nil
+	case '/':
+		l.slash = true
+		return nil, true, nil
+	}
+
+	value = l.token()
+
+	// separator after value
+	for l.pos < len(l.record) && isBlank(l.record[l.pos]) {
+		l.pos++
+	}
+	if l.pos < len(l.record) && l.record[l.pos] == ',' {
+		l.pos++
+	}
+
+	// repeated values
+	if i := repeatCount(value); i > 0 {
+		r, err := strconv.Atoi(string(value[:i]))
+		if err != nil || r == 0 {
+			return nil, true, fmt.Errorf("Bad repeat count %q in list input", value[:i])
+		}
+		l.value = value[i+1:]
+		if len(l.value) == 0 {
+			l.value = nil
+		}
+		l.repeat = r - 1
+		return l.value, l.value == nil, nil
+	}
+	return value, false, nil
+}
+
+// token return value until separator. Quoted characters and complex
+// values are started at begin of value or after repeat count.
+func (l *listReader) token() []byte {
+	start := l.pos
+	for l.pos < len(l.record) {
+		c := l.record[l.pos]
+		atStart := l.pos == start || l.record[l.pos-1] == '*' && repeatCount(l.record[start:l.pos]) > 0
+		switch {
+		case (c == '\'' || c == '"') && atStart:
+			for l.pos++; l.pos < len(l.record); l.pos++ {
+				if l.record[l.pos] != c {
+					continue
+				}
+				if l.pos+1 < len(l.record) && l.record[l.pos+1] == c {
+					l.pos++
+					continue
+				}
+				break
+			}
+			l.pos++
+			continue
+		case c == '(' && atStart:
+			for l.pos < len(l.record) && l.record[l.pos] != ')' {
+				l.pos++
+			}
+			l.pos++
+			continue
+		case isBlank(c) || c == ',' || c == '/':
+			return l.record[start:l.pos]
+		}
+		l.pos++
+	}
+	if l.pos > len(l.record) {
+		l.pos = len(l.record)
+	}
+	return l.record[start:l.pos]
+}
+
+// repeatCount return position of '*' after repeat count in value,
+// or -1 if value is not repeated
+func repeatCount(value []byte) int {
+	i := 0
+	for i < len(value) && '0' <= value[i] && value[i] <= '9' {
+		i++
+	}
+	if i == 0 || i == len(value) || value[i] != '*' {
+		return -1
+	}
+	return i
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r'
+}
+
+// setValue set value of input in item t
+func setValue(t reflect.Value, value []byte) error {
+	if isCharacter(t) {
+		if len(value) > 1 && (value[0] == '\'' || value[0] == '"') {
+			q := value[0]
+			value = bytes.Replace(value[1:len(value)-1], []byte{q, q}, []byte{q}, -1)
+		}
+		setCharacter(t, value)
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(string(value), 10, t.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("Bad integer %q", value)
+		}
+		t.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		x, err := parseReal(value, t.Type().Bits())
+		if err != nil {
+			return err
+		}
+		t.SetFloat(x)
+	case reflect.Complex64, reflect.Complex128:
+		bits := t.Type().Bits() / 2
+		parts := bytes.Split(bytes.Trim(value, "()"), []byte{','})
+		if value[0] != '(' || value[len(value)-1] != ')' || len(parts) != 2 {
+			return fmt.Errorf("Bad complex %q", value)
+		}
+		re, err := parseReal(bytes.TrimSpace(parts[0]), bits)
+		if err != nil {
+			return err
+		}
+		im, err := parseReal(bytes.TrimSpace(parts[1]), bits)
+		if err != nil {
+			return err
+		}
+		t.SetComplex(complex(re, im))
+	case reflect.Bool:
+		b := bytes.TrimPrefix(value, []byte{'.'})
+		if len(b) == 0 {
+			return fmt.Errorf("Bad logical %q", value)
+		}
+		switch b[0] {
+		case 'T', 't':
+			t.SetBool(true)
+		case 'F', 'f':
+			t.SetBool(false)
+		default:
+			return fmt.Errorf("Bad logical %q", value)
+		}
+	default:
+		return fmt.Errorf("Not supported type %s", t.Type())
+	}
+	return nil
+}
+
+// parseReal return real value of input.
+//
+// Example:
+//
+//	1.5
+//	.5
+//	1.0D-3
+//	1.0-3
+func parseReal(value []byte, bits int) (float64, error) {
+	s := make([]byte, 0, len(value)+1)
+	for i, c := range value {
+		switch c {
+		case 'D', 'd', 'Q', 'q':
+			c = 'E'
+		case '+', '-':
+			// exponent without letter
+			if i > 0 && (value[i-1] == '.' || '0' <= value[i-1] && value[i-1] <= '9') {
+				s = append(s, 'E')
+			}
+		}
+		s = append(s, c)
+	}
+	x, err := strconv.ParseFloat(string(s), bits)
+	if err != nil {
+		return 0, fmt.Errorf("Bad real %q", value)
+	}
+	return x, nil
+}
+
+// setCharacter copy value in character item with blank padding
+func setCharacter(t reflect.Value, value []byte) {
+	if t.Kind() == reflect.Slice && t.Len() == 0 {
+		t.SetBytes(append([]byte(nil), value...))
+		return
+	}
+	for i := 0; i < t.Len(); i++ {
+		c := byte(' ')
+		if i < len(value) {
+			c = value[i]
+		}
+		t.Index(i).SetUint(uint64(c))
+	}
+}
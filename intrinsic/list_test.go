@@ -0,0 +1,124 @@
+package intrinsic
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestListWrite(t *testing.T) {
+	tcs := []struct {
+		items []interface{}
+		out   string
+	}{
+		{nil, ""},
+		{[]interface{}{5}, "           5"},
+		{[]interface{}{1, -2}, "           1          -2"},
+		{[]interface{}{int8(1), int16(2), int64(3)}, "    1      2                    3"},
+		{[]interface{}{[]byte("N ="), 5}, " N =           5"},
+		{[]interface{}{5, []byte("x")}, "           5 x"},
+		{[]interface{}{[]byte("a"), "b", [][]byte{[]byte("cd")}}, " abcd"},
+		{[]interface{}{CHAR(66), []byte("C"), 5}, " BC           5"},
+		{[]interface{}{true, false}, " T F"},
+		{[]interface{}{1.0}, "   1.0000000000000000     "},
+		{[]interface{}{-1.0}, "  -1.0000000000000000     "},
+		{[]interface{}{0.0}, "   0.0000000000000000     "},
+		{[]interface{}{3.14159}, "   3.1415899999999999     "},
+		{[]interface{}{1.0e-5}, "   1.0000000000000001E-005"},
+		{[]interface{}{1.0e20}, "   1.0000000000000000E+020"},
+		{[]interface{}{123456789.0}, "   123456789.00000000     "},
+		{[]interface{}{float32(1.0)}, "   1.00000000    "},
+		{[]interface{}{float32(1.0e-5)}, "   9.99999975E-06"},
+		{[]interface{}{math.Inf(1)}, "                  Infinity"},
+		{[]interface{}{complex64(complex(1, 2))}, "             (1.00000000,2.00000000)"},
+		{[]interface{}{complex(1, -0.5)}, "             (1.0000000000000000,-0.50000000000000000)"},
+		{[]interface{}{&[]int{1, 2}, [][]int{{3, 4}, {5, 6}}},
+			"           1           2           3           5           4           6"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.out, func(t *testing.T) {
+			out := string(listWrite(tc.items, 0))
+			if out != tc.out {
+				t.Errorf("Not same:\n%q\n%q", out, tc.out)
+			}
+		})
+	}
+
+	// DELIM='APOSTROPHE'
+	out := string(listWrite([]interface{}{[]byte("it's"), "a", 1}, '\''))
+	if expect := " 'it''s' 'a'           1"; out != expect {
+		t.Errorf("Not same:\n%q\n%q", out, expect)
+	}
+}
+
+func TestListRead(t *testing.T) {
+	var (
+		i, j, k int
+		x, y    float64
+		c       complex128
+		b       bool
+		s       = []byte("12345")
+		a       = make([]float64, 3)
+	)
+	input := "  1, ,3 2*2.5D0\n" +
+		"\n" +
+		"(1.5, -2) .TRUE. 'it''s' 3*7 ignored\n" +
+		"5 /\n" +
+		"6\n"
+	next := readRecord(strings.NewReader(input))
+	j = -1
+	if err := listRead(next, []interface{}{&i, &j, &k, &x, &y, &c, &b, &s, a}); err != nil {
+		t.Fatal(err)
+	}
+	if i != 1 || j != -1 || k != 3 || x != 2.5 || y != 2.5 ||
+		c != complex(1.5, -2) || !b || string(s) != "it's " ||
+		a[0] != 7 || a[1] != 7 || a[2] != 7 {
+		t.Errorf("Not valid: %v %v %v %v %v %v %v %q %v", i, j, k, x, y, c, b, s, a)
+	}
+
+	// rest of record is skipped, slash terminates input
+	i, j = 0, -1
+	if err := listRead(next, []interface{}{&i, &j}); err != nil {
+		t.Fatal(err)
+	}
+	if i != 5 || j != -1 {
+		t.Errorf("Not valid: %v %v", i, j)
+	}
+
+	// characters without quotes
+	if err := listRead(next, []interface{}{s}); err != nil {
+		t.Fatal(err)
+	}
+	if string(s) != "6    " {
+		t.Errorf("Not valid: %q", s)
+	}
+
+	// end of file
+	if err := listRead(next, []interface{}{&i}); err != io.EOF {
+		t.Errorf("Not end of file: %v", err)
+	}
+}
+
+func TestListReadFail(t *testing.T) {
+	tcs := []struct {
+		input string
+		item  interface{}
+	}{
+		{"1.5", new(int)},
+		{"abc", new(float64)},
+		{"X", new(bool)},
+		{"1.5", new(complex128)},
+		{"0*1", new(int)},
+		{"1", 5},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			next := readRecord(bytes.NewReader([]byte(tc.input)))
+			if err := listRead(next, []interface{}{tc.item}); err == nil {
+				t.Errorf("error is empty")
+			}
+		})
+	}
+}
@@ -1,107 +1,84 @@
 package intrinsic
 
 import (
-	"fmt"
 	"math"
 	"math/cmplx"
 )
 
-const (
-	eps = 1.1920928955078125e-07 // math.Pow(2,-23) = 1.1920928955078125e-07 (EPSILON)
-)
-
-func MIN(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+// Integer is type of INTEGER values
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
 }
 
-func castToFloat64(w interface{}) float64 {
-	switch v := w.(type) {
-	case float64:
-		return v
-	case *float64:
-		return *v
-	case float32:
-		return float64(v)
-	case *float32:
-		return float64(*v)
-	case int:
-		return float64(v)
-	case *int:
-		return float64(*v)
-		// 	case complex128:
-		// 		r := real(v)
-		// 		i := imag(v)
-		// 		return float64(math.Mod(r, 2) - math.Mod(i, 2))
-		// 	case *complex128:
-		// 		r := real(*v)
-		// 		i := imag(*v)
-		// 		return float64(math.Mod(r, 2) - math.Mod(i, 2))
-	default:
-		panic(fmt.Errorf("cannot cast: %#v", w))
-	}
+// Real is type of REAL and DOUBLE PRECISION values
+type Real interface {
+	~float32 | ~float64
 }
 
-func SQRT(a interface{}) float64 {
-	A := castToFloat64(a)
-	return math.Sqrt(A)
+// Number is type of INTEGER, REAL and DOUBLE PRECISION values
+type Number interface {
+	Integer | Real
 }
 
-func MAX(a, b interface{}) float64 {
-	return math.Max(castToFloat64(a), castToFloat64(b))
-}
+// Generic numeric intrinsic functions. Type of result is type of
+// arguments like in Fortran, so integer arguments give integer result:
+//
+//	MAX(2, 5)      - 5 as int
+//	MAX(2.0, 5.5)  - 5.5 as float64
+//	ABS(int8(-3))  - 3 as int8
 
-func EPSILON(f float64) float64 {
-	return eps
-}
-
-func CONJG(c complex128) complex128 {
-	return cmplx.Conj(complex128(c))
+// SQRT is square root
+func SQRT[T Real](a T) T {
+	return T(math.Sqrt(float64(a)))
 }
 
-func DCONJG(c complex128) complex128 {
-	return cmplx.Conj(c)
+// MAX is largest value of arguments
+func MAX[T Number](a T, b ...T) T {
+	for _, v := range b {
+		if v > a {
+			a = v
+		}
+	}
+	return a
 }
 
-func DBLE(a interface{}) float64 {
-	switch a := a.(type) {
-	case int:
-		return float64(a)
-	case int32:
-		return float64(a)
-	case int64:
-		return float64(a)
-	case float32:
-		return float64(a)
-	case complex64:
-		return float64(real(a))
-	case complex128:
-		return float64(real(a))
-	case float64:
-		return a
+// MIN is smallest value of arguments
+func MIN[T Number](a T, b ...T) T {
+	for _, v := range b {
+		if v < a {
+			a = v
+		}
 	}
-	panic(fmt.Errorf("Cannot find type : %T", a))
+	return a
 }
 
-func ABS(a interface{}) float64 {
-	return math.Abs(castToFloat64(a))
+// ABS is absolute value. Absolute value of negative zero is zero.
+func ABS[T Number](a T) T {
+	if a <= 0 {
+		return 0 - a
+	}
+	return a
 }
 
-func CABS(a complex128) float64 {
-	return cmplx.Abs(a)
+// DBLE is conversion to DOUBLE PRECISION
+func DBLE[T Number](a T) float64 {
+	return float64(a)
 }
 
-func SIGN(a float64) float64 {
-	return math.Copysign(1, a)
+// CMPLX is conversion to COMPLEX. Imaginary part is zero, if it
+// is not present.
+func CMPLX[T Number](re T, im ...T) complex128 {
+	var i float64
+	if len(im) > 0 {
+		i = float64(im[0])
+	}
+	return complex(float64(re), i)
 }
 
-func MOD(a, b int) int {
-	return a % b
+func CONJG(c complex128) complex128 {
+	return cmplx.Conj(complex128(c))
 }
 
-func CMPLX(a interface{}) complex128 {
-	A := castToFloat64(a)
-	return complex(A, 0)
+func DCONJG(c complex128) complex128 {
+	return cmplx.Conj(c)
 }
@@ -0,0 +1,147 @@
+package intrinsic
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Numeric inquiry and manipulation intrinsic functions. Values are
+// defined by model of Go type of argument: float32 is REAL of single
+// precision, float64 is DOUBLE PRECISION, integer types by size.
+//
+//	EPSILON(float32(0))  - 1.1920929e-07 as float32
+//	EPSILON(0.0)         - 2.220446049250313e-16 as float64
+//	HUGE(int32(0))       - 2147483647 as int32
+
+// model is parameters of real model: x = fraction * 2**exponent, where
+// fraction is in [0.5, 1) and has amount of binary digits
+type model struct {
+	digits      int
+	minExponent int
+	maxExponent int
+}
+
+// realModel return model of real type
+func realModel[T Number](x T) model {
+	if unsafe.Sizeof(x) == 4 {
+		return model{digits: 24, minExponent: -125, maxExponent: 128}
+	}
+	return model{digits: 53, minExponent: -1021, maxExponent: 1024}
+}
+
+// isReal return true for real types
+func isReal[T Number](x T) bool {
+	switch any(x).(type) {
+	case float32, float64:
+		return true
+	}
+	return false
+}
+
+// EPSILON is smallest value e, where 1 + e is not equal 1
+func EPSILON[T Real](x T) T {
+	return T(math.Ldexp(1, 1-realModel(x).digits))
+}
+
+// TINY is smallest positive normalized value
+func TINY[T Real](x T) T {
+	return T(math.Ldexp(1, realModel(x).minExponent-1))
+}
+
+// integerDigits return amount of binary digits of integer type without
+// sign bit. Type int is INTEGER of fortran with size 32 bits.
+func integerDigits[T Number](x T) int {
+	if _, ok := any(x).(int); ok {
+		return 31
+	}
+	return int(unsafe.Sizeof(x))*8 - 1
+}
+
+// HUGE is largest value
+func HUGE[T Number](x T) T {
+	if !isReal(x) {
+		return T(mask(integerDigits(x)))
+	}
+	m := realModel(x)
+	h := math.Ldexp(1-math.Ldexp(1, -m.digits), m.maxExponent)
+	return T(h)
+}
+
+// DIGITS is amount of significant binary digits
+func DIGITS[T Number](x T) int {
+	if isReal(x) {
+		return realModel(x).digits
+	}
+	return integerDigits(x)
+}
+
+// RADIX is base of model, always 2
+func RADIX[T Number](x T) int {
+	return 2
+}
+
+// PRECISION is amount of significant decimal digits
+func PRECISION[T Real](x T) int {
+	return int(float64(realModel(x).digits-1) * math.Log10(2))
+}
+
+// RANGE is decimal exponent range
+func RANGE[T Number](x T) int {
+	if !isReal(x) {
+		return int(math.Log10(float64(HUGE(x))))
+	}
+	tiny := math.Ldexp(1, realModel(x).minExponent-1)
+	return int(math.Min(math.Log10(float64(HUGE(x))), -math.Log10(tiny)))
+}
+
+// MAXEXPONENT is largest exponent of model
+func MAXEXPONENT[T Real](x T) int {
+	return realModel(x).maxExponent
+}
+
+// MINEXPONENT is smallest exponent of model
+func MINEXPONENT[T Real](x T) int {
+	return realModel(x).minExponent
+}
+
+// EXPONENT is exponent of value in model. Exponent of zero is zero.
+func EXPONENT[T Real](x T) int {
+	_, e := math.Frexp(float64(x))
+	return e
+}
+
+// FRACTION is fraction of value in model
+func FRACTION[T Real](x T) T {
+	f, _ := math.Frexp(float64(x))
+	return T(f)
+}
+
+// SCALE is value multiplied by 2**i
+func SCALE[T Real](x T, i int) T {
+	return T(math.Ldexp(float64(x), i))
+}
+
+// SPACING is distance between value and nearest value of model.
+// Spacing of zero or very small value is TINY.
+func SPACING[T Real](x T) T {
+	if x == 0 {
+		return TINY(x)
+	}
+	s := T(math.Ldexp(1, EXPONENT(x)-realModel(x).digits))
+	if s < TINY(x) {
+		return TINY(x)
+	}
+	return s
+}
+
+// NEAREST is nearest different value in direction of sign of s
+func NEAREST[T Real](x T, s float64) T {
+	inf := math.Inf(1)
+	if s < 0 {
+		inf = math.Inf(-1)
+	}
+	if unsafe.Sizeof(x) == 4 {
+		return T(math.Nextafter32(float32(x), float32(inf)))
+	}
+	return T(math.Nextafter(float64(x), inf))
+}
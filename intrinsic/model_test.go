@@ -0,0 +1,67 @@
+package intrinsic
+
+import "testing"
+
+func TestModel(t *testing.T) {
+	// results of gfortran for REAL, DOUBLE PRECISION, INTEGER,
+	// INTEGER*4 and INTEGER*8
+	var (
+		r float32
+		d float64
+	)
+	tcs := []struct {
+		name   string
+		result interface{}
+		expect interface{}
+	}{
+		{"EPSILON(R)", EPSILON(r), float32(1.1920929e-07)},
+		{"EPSILON(D)", EPSILON(d), 2.220446049250313e-16},
+		{"TINY(R)", TINY(r), float32(1.17549435e-38)},
+		{"TINY(D)", TINY(d), 2.2250738585072014e-308},
+		{"HUGE(R)", HUGE(r), float32(3.40282347e+38)},
+		{"HUGE(D)", HUGE(d), 1.7976931348623157e+308},
+		{"HUGE(I4)", HUGE(int32(0)), int32(2147483647)},
+		{"HUGE(I8)", HUGE(int64(0)), int64(9223372036854775807)},
+		{"HUGE(I)", HUGE(0), 2147483647},
+		{"HUGE(I2)", HUGE(int16(0)), int16(32767)},
+		{"DIGITS(R)", DIGITS(r), 24},
+		{"DIGITS(D)", DIGITS(d), 53},
+		{"DIGITS(I4)", DIGITS(int32(0)), 31},
+		{"DIGITS(I8)", DIGITS(int64(0)), 63},
+		{"DIGITS(I)", DIGITS(0), 31},
+		{"RADIX(D)", RADIX(d), 2},
+		{"RADIX(I4)", RADIX(int32(0)), 2},
+		{"PRECISION(R)", PRECISION(r), 6},
+		{"PRECISION(D)", PRECISION(d), 15},
+		{"RANGE(R)", RANGE(r), 37},
+		{"RANGE(D)", RANGE(d), 307},
+		{"RANGE(I4)", RANGE(int32(0)), 9},
+		{"RANGE(I8)", RANGE(int64(0)), 18},
+		{"RANGE(I)", RANGE(0), 9},
+		{"MAXEXPONENT(R)", MAXEXPONENT(r), 128},
+		{"MAXEXPONENT(D)", MAXEXPONENT(d), 1024},
+		{"MINEXPONENT(R)", MINEXPONENT(r), -125},
+		{"MINEXPONENT(D)", MINEXPONENT(d), -1021},
+		{"EXPONENT(8.0)", EXPONENT(8.0), 4},
+		{"EXPONENT(0.3)", EXPONENT(float32(0.3)), -1},
+		{"EXPONENT(0.0)", EXPONENT(0.0), 0},
+		{"FRACTION(8.0)", FRACTION(8.0), 0.5},
+		{"FRACTION(-3.0)", FRACTION(float32(-3)), float32(-0.75)},
+		{"SCALE(3.0,2)", SCALE(float32(3), 2), float32(12)},
+		{"SCALE(1D0,-1074)", SCALE(1.0, -1074), 5e-324},
+		{"SPACING(1.0)", SPACING(float32(1)), float32(1.1920929e-07)},
+		{"SPACING(1D0)", SPACING(1.0), 2.220446049250313e-16},
+		{"SPACING(1000D0)", SPACING(1000.0), 1.1368683772161603e-13},
+		{"SPACING(0.0)", SPACING(r), float32(1.17549435e-38)},
+		{"SPACING(1D-310)", SPACING(1e-310), 2.2250738585072014e-308},
+		{"NEAREST(1.0,1.0)", NEAREST(float32(1), 1), float32(1.0000001)},
+		{"NEAREST(1.0,-1.0)", NEAREST(float32(1), -1), float32(0.99999994)},
+		{"NEAREST(1D0,1D0)", NEAREST(1.0, 1), 1.0000000000000002},
+		{"NEAREST(0D0,-1D0)", NEAREST(0.0, -1), -5e-324},
+	}
+	for _, tc := range tcs {
+		if tc.result != tc.expect {
+			t.Errorf("Not valid result of %s: %v != %v", tc.name, tc.result, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Namelist is group of variables of NAMELIST statement. Items are
+// pointers to variables or slices with names in Names.
+//
+// Example:
+//
+//	NAMELIST /PARAMS/ A, B, N
+//
+// is:
+//
+//	Namelist{Name: "PARAMS", Names: []string{"A", "B", "N"},
+//		Items: []interface{}{&(*A), &(*B), &(*N)}}
+type Namelist struct {
+	Name  string
+	Names []string
+	Items []interface{}
+}
+
+// WRITENML is namelist output of group in unit.
+// If iostat is nil, then error of output is panic.
+//
+// Output is in layout of gfortran:
+//
+//	&PARAMS
+//	 A=  1.5000000000000000     ,
+//	 N=          3,
+//	 V= 2*0.0000000000000000       ,  2.0000000000000000     ,
+//	 /
+//
+// Rules:
+//   - each variable is started from new record;
+//   - values are written like items of list-directed output without
+//     separator and followed by comma, repeated values are written
+//     as r*value without leading blanks;
+//   - characters are in quotes, if delimiter of unit is not defined.
+func WRITENML(unit interface{}, iostat *int, nml Namelist) {
+	DefaultUnits.WRITENML(unit, iostat, nml)
+}
+
+// WRITENML is function WRITENML for table of units
+func (us *Units) WRITENML(unit interface{}, iostat *int, nml Namelist) {
+	if records, ok := internal(unit); ok {
+		status(iostat, putInternal(records, nml.write('"')))
+		return
+	}
+	us = us.get()
+	status(iostat, us.writenml(unit, nml))
+}
+
+func (us *Units) writenml(unit interface{}, nml Namelist) error {
+	u, rec, err := us.lockedConnection(unit, "FORMATTED")
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	if rec != 0 {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Namelist output is not allowed for direct access")}
+	}
+	if err := u.writable(); err != nil {
+		return err
+	}
+	delim := u.delim
+	if delim == 0 {
+		delim = '"'
+	}
+	var buf bytes.Buffer
+	for _, record := range nml.write(delim) {
+		buf.Write(record)
+		buf.WriteByte('\n')
+	}
+	err = u.write(buf.Bytes())
+	return err
+}
+
+// write return records of namelist output
+func (nml Namelist) write(delim byte) (records [][]byte) {
+	records = append(records, []byte("&"+strings.ToUpper(nml.Name)))
+	for i := range nml.Items {
+		var values [][]byte
+		elements(reflect.ValueOf(nml.Items[i]), func(v reflect.Value) {
+			item := listItem(v)
+			if isCharacter(v) {
+				d := []byte{delim}
+				item = append(append(d, bytes.Replace(item, d, []byte{delim, delim}, -1)...), delim)
+			}
+			values = append(values, item)
+		})
+		record := []byte(" " + strings.ToUpper(nml.Names[i]) + "=")
+		for k := 0; k < len(values); {
+			r := 1
+			for k+r < len(values) && bytes.Equal(values[k], values[k+r]) {
+				r++
+			}
+			value := values[k]
+			if r > 1 {
+				// value after repeat count is without leading blanks
+				record = append(record, " "+strconv.Itoa(r)+"*"...)
+				value = bytes.TrimLeft(value, " ")
+				value = append(value, bytes.Repeat([]byte(" "), len(values[k])-len(value))...)
+			}
+			record = append(record, value...)
+			record = append(record, ',')
+			k += r
+		}
+		records = append(records, record)
+	}
+	return append(records, []byte(" /"))
+}
+
+// READNML is namelist input of group from unit.
+// If iostat is nil, then error of input is panic.
+//
+// Rules:
+//   - records before record with &NAME of group are skipped;
+//   - names of variables are not case-sensitive;
+//   - values of variable are like list-directed input with repeat
+//     counts and null values;
+//   - element of array is started by subscripts NAME(I,J)=, next
+//     values are for next elements of array;
+//   - input is terminated by slash or &END.
+//
+// Example of input:
+//
+//	&PARAMS a=1.5, V(2)=2*3.0 n=3 /
+func READNML(unit interface{}, iostat *int, nml Namelist) {
+	DefaultUnits.READNML(unit, iostat, nml)
+}
+
+// READNML is function READNML for table of units
+func (us *Units) READNML(unit interface{}, iostat *int, nml Namelist) {
+	us = us.get()
+	status(iostat, us.readnml(unit, nml))
+}
+
+func (us *Units) readnml(unit interface{}, nml Namelist) error {
+	if records, ok := internal(unit); ok {
+		return nml.read(internalRecords(records))
+	}
+	u, rec, err := us.lockedConnection(unit, "FORMATTED")
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	if rec != 0 {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Namelist input is not allowed for direct access")}
+	}
+	if u.action == "WRITE" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot read from file opened for WRITE")}
+	}
+	return nml.read(readRecord(u.reader()))
+}
+
+// read namelist input from records
+func (nml Namelist) read(next func() ([]byte, error)) error {
+	l := listReader{next: next}
+
+	// find begin of group
+	for {
+		record, err := next()
+		if err != nil {
+			return err
+		}
+		s := bytes.TrimLeft(record, " \t")
+		if len(s) > 0 && (s[0] == '&' || s[0] == '$') {
+			name := s[1:]
+			if i := bytes.IndexAny(name, " \t,/"); i >= 0 {
+				name = name[:i]
+			}
+			if strings.EqualFold(string(name), nml.Name) {
+				l.record, l.pos = record, len(record)-len(s)+1+len(name)
+				break
+			}
+		}
+	}
+
+	for {
+		if err := l.skip(); err != nil {
+			return err
+		}
+		if l.end() {
+			return nil
+		}
+		name, index, err := l.name()
+		if err != nil {
+			return err
+		}
+		var targets []reflect.Value
+		for i := range nml.Names {
+			if strings.EqualFold(nml.Names[i], name) {
+				elements(reflect.ValueOf(nml.Items[i]), func(v reflect.Value) {
+					targets = append(targets, v)
+				})
+				if targets, err = subscript(nml.Items[i], targets, index); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		if targets == nil {
+			return ioError{code: iostatReadValue, err: fmt.Errorf(
+				"Cannot match namelist object name %s", name)}
+		}
+
+		// values until next name or end of group
+		for k := 0; ; k++ {
+			if l.repeat == 0 {
+				if err := l.skip(); err != nil {
+					return err
+				}
+				if l.end() || l.isName() {
+					break
+				}
+			}
+			if k >= len(targets) {
+				return ioError{code: iostatReadValue, err: fmt.Errorf(
+					"Too many values for namelist object %s", name)}
+			}
+			value, null, err := l.item()
+			if err != nil {
+				return err
+			}
+			if null {
+				continue
+			}
+			if err := setValue(targets[k], value); err != nil {
+				return ioError{code: iostatReadValue, err: fmt.Errorf(
+					"%v for namelist object %s", err, name)}
+			}
+		}
+	}
+}
+
+// skip blanks and ends of records
+func (l *listReader) skip() (err error) {
+	for {
+		for l.pos < len(l.record) && isBlank(l.record[l.pos]) {
+			l.pos++
+		}
+		if l.pos < len(l.record) {
+			return nil
+		}
+		if l.record, err = l.next(); err != nil {
+			return
+		}
+		l.pos = 0
+	}
+}
+
+// end return true for end of group: slash or &END
+func (l *listReader) end() bool {
+	rest := l.record[l.pos:]
+	return rest[0] == '/' ||
+		len(rest) >= 4 && (rest[0] == '&' || rest[0] == '$') &&
+			strings.EqualFold(string(rest[1:4]), "END")
+}
+
+// isName return true, if next is name of variable with equal sign
+func (l *listReader) isName() bool {
+	pos := l.pos
+	_, _, err := l.name()
+	l.pos = pos
+	return err == nil
+}
+
+// name return name of variable and subscripts before equal sign
+func (l *listReader) name() (name string, index []int, err error) {
+	start := l.pos
+	for l.pos < len(l.record) {
+		c := l.record[l.pos]
+		if !(c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			break
+		}
+		l.pos++
+	}
+	name = string(l.record[start:l.pos])
+	if name == "" || '0' <= name[0] && name[0] <= '9' {
+		return "", nil, ioError{code: iostatReadValue, err: fmt.Errorf(
+			"Not valid name of namelist object %q", l.record[start:])}
+	}
+	if l.pos < len(l.record) && l.record[l.pos] == '(' {
+		end := bytes.IndexByte(l.record[l.pos:], ')')
+		if end < 0 {
+			return "", nil, ioError{code: iostatReadValue, err: fmt.Errorf(
+				"Not valid subscripts of namelist object %s", name)}
+		}
+		for _, s := range strings.Split(string(l.record[l.pos+1:l.pos+end]), ",") {
+			i, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return "", nil, ioError{code: iostatReadValue, err: fmt.Errorf(
+					"Not valid subscripts of namelist object %s", name)}
+			}
+			index = append(index, i)
+		}
+		l.pos += end + 1
+	}
+	for l.pos < len(l.record) && isBlank(l.record[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.record) || l.record[l.pos] != '=' {
+		return "", nil, ioError{code: iostatReadValue, err: fmt.Errorf(
+			"Equal sign is not found after namelist object %s", name)}
+	}
+	l.pos++
+	return
+}
+
+// subscript return elements of array from element with index
+func subscript(item interface{}, targets []reflect.Value, index []int) ([]reflect.Value, error) {
+	if len(index) == 0 {
+		return targets, nil
+	}
+	// dimensions of array
+	var dims []int
+	for v := reflect.ValueOf(item); ; v = v.Index(0) {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array || isCharacter(v) || v.Len() == 0 {
+			break
+		}
+		dims = append(dims, v.Len())
+	}
+	if len(dims) != len(index) {
+		return nil, ioError{code: iostatReadValue, err: fmt.Errorf(
+			"Not valid amount of subscripts %v for dimensions %v", index, dims)}
+	}
+	offset, stride := 0, 1
+	for k := range index {
+		if index[k] < 1 || dims[k] < index[k] {
+			return nil, ioError{code: iostatReadValue, err: fmt.Errorf(
+				"Subscripts %v are out of dimensions %v", index, dims)}
+		}
+		offset += (index[k] - 1) * stride
+		stride *= dims[k]
+	}
+	return targets[offset:], nil
+}
@@ -0,0 +1,117 @@
+package intrinsic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNamelistWrite(t *testing.T) {
+	n, x := 3, 1.5
+	v := []float64{0, 0, 2}
+	s := []byte("it's")
+	nml := Namelist{Name: "params", Names: []string{"N", "X", "V", "S"},
+		Items: []interface{}{&n, &x, &v, &s}}
+	expect := []string{
+		"&PARAMS",
+		" N=          3,",
+		" X=  1.5000000000000000     ,",
+		" V= 2*0.0000000000000000       ,  2.0000000000000000     ,",
+		` S="it's",`,
+		" /",
+	}
+	records := nml.write('"')
+	if len(records) != len(expect) {
+		t.Fatalf("Not valid amount of records: %q", records)
+	}
+	for i := range records {
+		if string(records[i]) != expect[i] {
+			t.Errorf("Not valid record %d:\n%q\n%q", i, records[i], expect[i])
+		}
+	}
+
+	// output is valid input
+	n, x, v, s = 0, 0, []float64{-1, -1, -1}, []byte("    ")
+	if err := nml.read(internalRecords(records)); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || x != 1.5 || v[0] != 0 || v[1] != 0 || v[2] != 2 || string(s) != "it's" {
+		t.Errorf("Not valid input: %d %v %v %q", n, x, v, s)
+	}
+}
+
+func TestNamelistRead(t *testing.T) {
+	var (
+		n int
+		x float64
+		b bool
+		v []float64
+		a [][]int
+		s []byte
+	)
+	nml := Namelist{Name: "PARAMS", Names: []string{"N", "X", "B", "V", "A", "S"},
+		Items: []interface{}{&n, &x, &b, &v, &a, &s}}
+	tcs := []struct {
+		input string
+		check func() bool
+	}{
+		{"&PARAMS N=5 /", func() bool { return n == 5 && x == -1 }},
+		{"&params n = 5, x=2.5D0 b=.true. /", func() bool { return n == 5 && x == 2.5 && b }},
+		{"other record\n&OTHER N=1 /\n &PARAMS\n x=1.5,\n n=7\n /", func() bool { return n == 7 && x == 1.5 }},
+		{"&PARAMS V=1,2,3 /", func() bool { return v[0] == 1 && v[1] == 2 && v[2] == 3 }},
+		{"&PARAMS V(2)=2*5. /", func() bool { return v[0] == -1 && v[1] == 5 && v[2] == 5 }},
+		{"&PARAMS V=, 4 /", func() bool { return v[0] == -1 && v[1] == 4 && v[2] == -1 }},
+		{"&PARAMS A(1,2)=8 9 /", func() bool { return a[0][1] == 8 && a[1][1] == 9 && a[0][0] == -1 }},
+		{"&PARAMS S='a b' N=2 /", func() bool { return string(s) == "a b " && n == 2 }},
+		{"$PARAMS N=4 $END", func() bool { return n == 4 }},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			n, x, b = -1, -1, false
+			v = []float64{-1, -1, -1}
+			a = [][]int{{-1, -1}, {-1, -1}}
+			s = []byte("xxxx")
+			if err := nml.read(readRecord(strings.NewReader(tc.input))); err != nil {
+				t.Fatal(err)
+			}
+			if !tc.check() {
+				t.Errorf("Not valid: %v %v %v %v %v %q", n, x, b, v, a, s)
+			}
+		})
+	}
+}
+
+func TestNamelistReadFail(t *testing.T) {
+	var n int
+	v := []int{0, 0}
+	nml := Namelist{Name: "G", Names: []string{"N", "V"}, Items: []interface{}{&n, &v}}
+	for _, tc := range []struct {
+		input string
+		code  int
+	}{
+		{"&G M=1 /", iostatReadValue},
+		{"&G N=1,2 /", iostatReadValue},
+		{"&G V(3)=1 /", iostatReadValue},
+		{"&G N=x /", iostatReadValue},
+		{"&H N=1 /", iostatEnd},
+	} {
+		err := nml.read(readRecord(strings.NewReader(tc.input)))
+		if code := iostatOf(err); code != tc.code {
+			t.Errorf("Not valid status for %q: %d %v", tc.input, code, err)
+		}
+	}
+}
+
+func TestNamelistUnits(t *testing.T) {
+	us := NewUnits()
+	out := us.Capture(6)
+	if err := us.Replace(5, strings.NewReader("&G N=2 /\n"), nil); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	nml := Namelist{Name: "G", Names: []string{"N"}, Items: []interface{}{&n}}
+	us.READNML(5, nil, nml)
+	us.WRITENML(6, nil, nml)
+	if s := out.String(); s != "&G\n N=          2,\n /\n" {
+		t.Errorf("Not valid output: %q", s)
+	}
+}
@@ -0,0 +1,268 @@
+package intrinsic
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Specific numeric intrinsic functions of FORTRAN 77. Types REAL and
+// DOUBLE PRECISION are float64, COMPLEX is complex128, so functions
+// for REAL and DOUBLE PRECISION are same.
+//
+// Generic functions are changed to specific functions by translator:
+//
+//	MAX(I, J)    - MAX0(I, J)
+//	MAX(X, Y, Z) - DMAX1(X, Y, Z)
+//	SIGN(X, Y)   - SIGN(X, Y)
+//	SIGN(I, J)   - ISIGN(I, J)
+
+// INT is conversion to INTEGER with truncation toward zero
+func INT(a float64) int { return int(a) }
+
+// IFIX is conversion REAL to INTEGER like INT
+func IFIX(a float64) int { return int(a) }
+
+// IDINT is conversion DOUBLE PRECISION to INTEGER like INT
+func IDINT(a float64) int { return int(a) }
+
+// FLOAT is conversion INTEGER to REAL
+func FLOAT(a int) float64 { return float64(a) }
+
+// SNGL is conversion DOUBLE PRECISION to REAL. Value is rounded to
+// single precision.
+func SNGL(a float64) float64 { return float64(float32(a)) }
+
+// AINT is truncation toward zero
+func AINT(a float64) float64 { return math.Trunc(a) }
+
+// DINT is truncation toward zero
+func DINT(a float64) float64 { return math.Trunc(a) }
+
+// ANINT is nearest whole number, half is rounded away from zero
+func ANINT(a float64) float64 { return math.Round(a) }
+
+// DNINT is nearest whole number, half is rounded away from zero
+func DNINT(a float64) float64 { return math.Round(a) }
+
+// NINT is nearest INTEGER, half is rounded away from zero
+func NINT(a float64) int { return int(math.Round(a)) }
+
+// IDNINT is nearest INTEGER, half is rounded away from zero
+func IDNINT(a float64) int { return int(math.Round(a)) }
+
+// IABS is absolute value of INTEGER
+func IABS(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// DABS is absolute value of DOUBLE PRECISION
+func DABS(a float64) float64 { return math.Abs(a) }
+
+// CABS is absolute value of COMPLEX
+func CABS(a complex128) float64 { return cmplx.Abs(a) }
+
+// MOD is remainder a-INT(a/p)*p of INTEGER
+func MOD(a, p int) int { return a % p }
+
+// AMOD is remainder a-AINT(a/p)*p of REAL
+func AMOD(a, p float64) float64 { return math.Mod(a, p) }
+
+// DMOD is remainder a-AINT(a/p)*p of DOUBLE PRECISION
+func DMOD(a, p float64) float64 { return math.Mod(a, p) }
+
+// ISIGN is absolute value of a with sign of b. Sign of zero b is plus.
+func ISIGN(a, b int) int {
+	if b < 0 {
+		return -IABS(a)
+	}
+	return IABS(a)
+}
+
+// SIGN is absolute value of a with sign of b. Sign of negative zero b
+// is minus like in gfortran.
+func SIGN(a, b float64) float64 { return math.Copysign(a, b) }
+
+// DSIGN is absolute value of a with sign of b like SIGN
+func DSIGN(a, b float64) float64 { return math.Copysign(a, b) }
+
+// IDIM is positive difference a-MIN(a,b) of INTEGER
+func IDIM(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return 0
+}
+
+// DIM is positive difference a-MIN(a,b) of REAL
+func DIM(a, b float64) float64 {
+	if a > b {
+		return a - b
+	}
+	return 0
+}
+
+// DDIM is positive difference a-MIN(a,b) of DOUBLE PRECISION
+func DDIM(a, b float64) float64 { return DIM(a, b) }
+
+// DPROD is DOUBLE PRECISION product of REAL values. Values are
+// rounded to single precision before product like in gfortran.
+func DPROD(a, b float64) float64 {
+	return float64(float32(a)) * float64(float32(b))
+}
+
+// MAX0 is largest value of INTEGER values
+func MAX0(a int, b ...int) int {
+	for _, v := range b {
+		if v > a {
+			a = v
+		}
+	}
+	return a
+}
+
+// AMAX1 is largest value of REAL values
+func AMAX1(a float64, b ...float64) float64 {
+	for _, v := range b {
+		if v > a {
+			a = v
+		}
+	}
+	return a
+}
+
+// DMAX1 is largest value of DOUBLE PRECISION values
+func DMAX1(a float64, b ...float64) float64 { return AMAX1(a, b...) }
+
+// AMAX0 is largest value of INTEGER values as REAL
+func AMAX0(a int, b ...int) float64 { return float64(MAX0(a, b...)) }
+
+// MAX1 is largest value of REAL values as INTEGER
+func MAX1(a float64, b ...float64) int { return int(AMAX1(a, b...)) }
+
+// MIN0 is smallest value of INTEGER values
+func MIN0(a int, b ...int) int {
+	for _, v := range b {
+		if v < a {
+			a = v
+		}
+	}
+	return a
+}
+
+// AMIN1 is smallest value of REAL values
+func AMIN1(a float64, b ...float64) float64 {
+	for _, v := range b {
+		if v < a {
+			a = v
+		}
+	}
+	return a
+}
+
+// DMIN1 is smallest value of DOUBLE PRECISION values
+func DMIN1(a float64, b ...float64) float64 { return AMIN1(a, b...) }
+
+// AMIN0 is smallest value of INTEGER values as REAL
+func AMIN0(a int, b ...int) float64 { return float64(MIN0(a, b...)) }
+
+// MIN1 is smallest value of REAL values as INTEGER
+func MIN1(a float64, b ...float64) int { return int(AMIN1(a, b...)) }
+
+// DSQRT is square root of DOUBLE PRECISION
+func DSQRT(a float64) float64 { return math.Sqrt(a) }
+
+// CSQRT is square root of COMPLEX
+func CSQRT(a complex128) complex128 { return cmplx.Sqrt(a) }
+
+// EXP is exponential of REAL
+func EXP(a float64) float64 { return math.Exp(a) }
+
+// DEXP is exponential of DOUBLE PRECISION
+func DEXP(a float64) float64 { return math.Exp(a) }
+
+// CEXP is exponential of COMPLEX
+func CEXP(a complex128) complex128 { return cmplx.Exp(a) }
+
+// ALOG is natural logarithm of REAL
+func ALOG(a float64) float64 { return math.Log(a) }
+
+// DLOG is natural logarithm of DOUBLE PRECISION
+func DLOG(a float64) float64 { return math.Log(a) }
+
+// CLOG is natural logarithm of COMPLEX
+func CLOG(a complex128) complex128 { return cmplx.Log(a) }
+
+// ALOG10 is common logarithm of REAL
+func ALOG10(a float64) float64 { return math.Log10(a) }
+
+// DLOG10 is common logarithm of DOUBLE PRECISION
+func DLOG10(a float64) float64 { return math.Log10(a) }
+
+// SIN is sine of REAL
+func SIN(a float64) float64 { return math.Sin(a) }
+
+// DSIN is sine of DOUBLE PRECISION
+func DSIN(a float64) float64 { return math.Sin(a) }
+
+// CSIN is sine of COMPLEX
+func CSIN(a complex128) complex128 { return cmplx.Sin(a) }
+
+// COS is cosine of REAL
+func COS(a float64) float64 { return math.Cos(a) }
+
+// DCOS is cosine of DOUBLE PRECISION
+func DCOS(a float64) float64 { return math.Cos(a) }
+
+// CCOS is cosine of COMPLEX
+func CCOS(a complex128) complex128 { return cmplx.Cos(a) }
+
+// TAN is tangent of REAL
+func TAN(a float64) float64 { return math.Tan(a) }
+
+// DTAN is tangent of DOUBLE PRECISION
+func DTAN(a float64) float64 { return math.Tan(a) }
+
+// ASIN is arcsine of REAL
+func ASIN(a float64) float64 { return math.Asin(a) }
+
+// DASIN is arcsine of DOUBLE PRECISION
+func DASIN(a float64) float64 { return math.Asin(a) }
+
+// ACOS is arccosine of REAL
+func ACOS(a float64) float64 { return math.Acos(a) }
+
+// DACOS is arccosine of DOUBLE PRECISION
+func DACOS(a float64) float64 { return math.Acos(a) }
+
+// ATAN is arctangent of REAL
+func ATAN(a float64) float64 { return math.Atan(a) }
+
+// DATAN is arctangent of DOUBLE PRECISION
+func DATAN(a float64) float64 { return math.Atan(a) }
+
+// ATAN2 is arctangent of a/b of REAL in range (-pi, pi]
+func ATAN2(a, b float64) float64 { return math.Atan2(a, b) }
+
+// DATAN2 is arctangent of a/b of DOUBLE PRECISION in range (-pi, pi]
+func DATAN2(a, b float64) float64 { return math.Atan2(a, b) }
+
+// SINH is hyperbolic sine of REAL
+func SINH(a float64) float64 { return math.Sinh(a) }
+
+// DSINH is hyperbolic sine of DOUBLE PRECISION
+func DSINH(a float64) float64 { return math.Sinh(a) }
+
+// COSH is hyperbolic cosine of REAL
+func COSH(a float64) float64 { return math.Cosh(a) }
+
+// DCOSH is hyperbolic cosine of DOUBLE PRECISION
+func DCOSH(a float64) float64 { return math.Cosh(a) }
+
+// TANH is hyperbolic tangent of REAL
+func TANH(a float64) float64 { return math.Tanh(a) }
+
+// DTANH is hyperbolic tangent of DOUBLE PRECISION
+func DTANH(a float64) float64 { return math.Tanh(a) }
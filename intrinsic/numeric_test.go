@@ -0,0 +1,91 @@
+package intrinsic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumeric(t *testing.T) {
+	// results of gfortran
+	tcs := []struct {
+		name   string
+		result interface{}
+		expect interface{}
+	}{
+		{"INT(-2.7)", INT(-2.7), -2},
+		{"IFIX(2.7)", IFIX(2.7), 2},
+		{"IDINT(-0.5D0)", IDINT(-0.5), 0},
+		{"FLOAT(3)", FLOAT(3), 3.0},
+		{"SNGL(0.1D0)", SNGL(0.1), float64(float32(0.1))},
+		{"AINT(-2.7)", AINT(-2.7), -2.0},
+		{"DINT(2.7D0)", DINT(2.7), 2.0},
+		{"ANINT(-2.5)", ANINT(-2.5), -3.0},
+		{"DNINT(0.5D0)", DNINT(0.5), 1.0},
+		{"NINT(2.5)", NINT(2.5), 3},
+		{"NINT(-2.5)", NINT(-2.5), -3},
+		{"IDNINT(-2.4D0)", IDNINT(-2.4), -2},
+		{"IABS(-4)", IABS(-4), 4},
+		{"DABS(-1.5D0)", DABS(-1.5), 1.5},
+		{"CABS((3,4))", CABS(complex(3, 4)), 5.0},
+		{"MOD(-7,3)", MOD(-7, 3), -1},
+		{"MOD(7,-3)", MOD(7, -3), 1},
+		{"AMOD(-7.5,2.0)", AMOD(-7.5, 2), -1.5},
+		{"DMOD(7.5D0,-2D0)", DMOD(7.5, -2), 1.5},
+		{"ISIGN(3,-2)", ISIGN(3, -2), -3},
+		{"ISIGN(-3,0)", ISIGN(-3, 0), 3},
+		{"SIGN(-2.0,1.0)", SIGN(-2, 1), 2.0},
+		{"SIGN(2.0,-0.0)", SIGN(2, math.Copysign(0, -1)), -2.0},
+		{"DSIGN(2D0,-1D0)", DSIGN(2, -1), -2.0},
+		{"IDIM(3,5)", IDIM(3, 5), 0},
+		{"IDIM(5,3)", IDIM(5, 3), 2},
+		{"DIM(1.5,0.5)", DIM(1.5, 0.5), 1.0},
+		{"DDIM(0.5D0,1.5D0)", DDIM(0.5, 1.5), 0.0},
+		{"DPROD(0.1,0.1)", DPROD(0.1, 0.1), 1.0000000298023226e-02},
+		{"MAX0(1,5,3)", MAX0(1, 5, 3), 5},
+		{"AMAX1(-1.0,-2.0)", AMAX1(-1, -2), -1.0},
+		{"DMAX1(1D0,2D0,3D0)", DMAX1(1, 2, 3), 3.0},
+		{"AMAX0(1,5,3)", AMAX0(1, 5, 3), 5.0},
+		{"MAX1(1.5,2.7)", MAX1(1.5, 2.7), 2},
+		{"MIN0(4,-2,3)", MIN0(4, -2, 3), -2},
+		{"AMIN1(1.0,2.0)", AMIN1(1, 2), 1.0},
+		{"DMIN1(3D0,2D0,1D0)", DMIN1(3, 2, 1), 1.0},
+		{"AMIN0(4,-2)", AMIN0(4, -2), -2.0},
+		{"MIN1(-1.5,2.0)", MIN1(-1.5, 2), -1},
+		{"DSQRT(2D0)", DSQRT(2), 1.4142135623730951},
+		{"CSQRT((-4,0))", CSQRT(complex(-4, 0)), complex(0, 2)},
+		{"EXP(1.0)", EXP(1), 2.718281828459045},
+		{"DEXP(0D0)", DEXP(0), 1.0},
+		{"ALOG(1.0)", ALOG(1), 0.0},
+		{"DLOG(2D0)", DLOG(2), 0.6931471805599453},
+		{"ALOG10(1000.0)", ALOG10(1000), 3.0},
+		{"DLOG10(0.01D0)", DLOG10(0.01), -2.0},
+		{"DSIN(0D0)", DSIN(0), 0.0},
+		{"DCOS(0D0)", DCOS(0), 1.0},
+		{"DTAN(0D0)", DTAN(0), 0.0},
+		{"DASIN(1D0)", DASIN(1), math.Pi / 2},
+		{"DACOS(-1D0)", DACOS(-1), math.Pi},
+		{"DATAN(1D0)", DATAN(1), math.Pi / 4},
+		{"ATAN2(1.0,-1.0)", ATAN2(1, -1), 2.356194490192345},
+		{"DATAN2(0D0,-1D0)", DATAN2(0, -1), math.Pi},
+		{"DSINH(0D0)", DSINH(0), 0.0},
+		{"DCOSH(0D0)", DCOSH(0), 1.0},
+		{"DTANH(0D0)", DTANH(0), 0.0},
+		{"MAX(2,5,3)", MAX(2, 5, 3), 5},
+		{"MAX(2.0,5.5)", MAX(2.0, 5.5), 5.5},
+		{"MIN(int8)", MIN(int8(4), -2), int8(-2)},
+		{"MIN(2.0,-1.5)", MIN(2.0, -1.5), -1.5},
+		{"ABS(-3)", ABS(-3), 3},
+		{"ABS(-2.5)", ABS(-2.5), 2.5},
+		{"ABS(-0.0)", math.Signbit(ABS(math.Copysign(0, -1))), false},
+		{"SQRT(4.0)", SQRT(4.0), 2.0},
+		{"SQRT(float32)", SQRT(float32(2.25)), float32(1.5)},
+		{"DBLE(3)", DBLE(3), 3.0},
+		{"CMPLX(2)", CMPLX(2), complex(2, 0)},
+		{"CMPLX(1.5,-1.0)", CMPLX(1.5, -1), complex(1.5, -1)},
+	}
+	for _, tc := range tcs {
+		if tc.result != tc.expect {
+			t.Errorf("Not valid result of %s: %v != %v", tc.name, tc.result, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package intrinsic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// status codes of OPEN, CLOSE statements, same as in gfortran
+const (
+	iostatOptionConflict = 5001
+	iostatBadOption      = 5002
+)
+
+// unit is connection of file to unit number
+type unit struct {
+	mu      sync.Mutex // lock of data transfer
+	file    stream
+	r       *bufio.Reader // buffer of sequential input from file
+	name    string
+	scratch bool
+	owned   bool // file is opened by OPEN and closed by CLOSE
+
+	// properties of connection
+	access string // SEQUENTIAL, DIRECT
+	form   string // FORMATTED, UNFORMATTED
+	action string // READ, WRITE, READWRITE
+	recl   int
+	blank  byte // 'N' - blanks are ignored, 'Z' - blanks are zeros
+	delim  byte // delimiter of characters in list-directed output
+	pad    bool // records of formatted input are padded by blanks
+}
+
+func preconnected(f *os.File, action string) *unit {
+	return &unit{
+		file:   f,
+		name:   f.Name(),
+		access: "SEQUENTIAL",
+		form:   "FORMATTED",
+		action: action,
+		blank:  'N',
+		pad:    true,
+	}
+}
+
+// isStandard return true for standard input, output and error
+func (u *unit) isStandard() bool {
+	return u.file == os.Stdin || u.file == os.Stdout || u.file == os.Stderr
+}
+
+// OpenSpec is specifiers of OPEN statement. Empty values are default
+// values of fortran.
+type OpenSpec struct {
+	FILE     []byte
+	STATUS   []byte // OLD, NEW, SCRATCH, REPLACE, UNKNOWN
+	ACCESS   []byte // SEQUENTIAL, DIRECT
+	FORM     []byte // FORMATTED, UNFORMATTED
+	ACTION   []byte // READ, WRITE, READWRITE
+	POSITION []byte // ASIS, REWIND, APPEND
+	BLANK    []byte // NULL, ZERO
+	DELIM    []byte // NONE, APOSTROPHE, QUOTE
+	PAD      []byte // YES, NO
+	RECL     int
+}
+
+// OPEN connect file to unit.
+// If iostat is nil, then error is panic.
+//
+// Example:
+//
+//	OPEN(NOUT, nil, OpenSpec{FILE: []byte("out.txt"), STATUS: []byte("UNKNOWN")})
+func OPEN(unit int, iostat *int, spec OpenSpec) {
+	DefaultUnits.OPEN(unit, iostat, spec)
+}
+
+// OPEN is function OPEN for table of units
+func (us *Units) OPEN(unit int, iostat *int, spec OpenSpec) {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	status(iostat, us.open(unit, spec))
+}
+
+// value return trimmed value of specifier in upper case or default value
+func value(b []byte, def string, values ...string) (string, error) {
+	s := string(bytes.ToUpper(bytes.TrimSpace(b)))
+	if s == "" {
+		return def, nil
+	}
+	for _, v := range values {
+		if s == v {
+			return s, nil
+		}
+	}
+	return "", ioError{code: iostatBadOption, err: fmt.Errorf(
+		"Bad specifier value %q, expected one of %v", s, values)}
+}
+
+func (us *Units) open(number int, spec OpenSpec) (err error) {
+	st, err := value(spec.STATUS, "UNKNOWN", "OLD", "NEW", "SCRATCH", "REPLACE", "UNKNOWN")
+	if err != nil {
+		return
+	}
+	u := unit{recl: spec.RECL, pad: true}
+	if u.access, err = value(spec.ACCESS, "SEQUENTIAL", "SEQUENTIAL", "DIRECT"); err != nil {
+		return
+	}
+	form := "FORMATTED"
+	if u.access == "DIRECT" {
+		form = "UNFORMATTED"
+	}
+	if u.form, err = value(spec.FORM, form, "FORMATTED", "UNFORMATTED"); err != nil {
+		return
+	}
+	if u.action, err = value(spec.ACTION, "READWRITE", "READ", "WRITE", "READWRITE"); err != nil {
+		return
+	}
+	position, err := value(spec.POSITION, "ASIS", "ASIS", "REWIND", "APPEND")
+	if err != nil {
+		return
+	}
+	blank, err := value(spec.BLANK, "NULL", "NULL", "ZERO")
+	if err != nil {
+		return
+	}
+	u.blank = blank[0]
+	if blank == "ZERO" {
+		u.blank = 'Z'
+	}
+	delim, err := value(spec.DELIM, "NONE", "NONE", "APOSTROPHE", "QUOTE")
+	if err != nil {
+		return
+	}
+	switch delim {
+	case "APOSTROPHE":
+		u.delim = '\''
+	case "QUOTE":
+		u.delim = '"'
+	}
+	pad, err := value(spec.PAD, "YES", "YES", "NO")
+	if err != nil {
+		return
+	}
+	u.pad = pad == "YES"
+	if u.access == "DIRECT" && u.recl <= 0 {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"RECL must be positive for direct access")}
+	}
+
+	u.name = string(bytes.TrimSpace(spec.FILE))
+	switch {
+	case st == "SCRATCH" && u.name != "":
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"FILE must not be present for STATUS='SCRATCH'")}
+	case st != "SCRATCH" && u.name == "":
+		u.name = fmt.Sprintf("fort.%d", number)
+	}
+
+	// unit is connected to other file
+	if old, ok := us.table[number]; ok {
+		if old.name == u.name && !old.scratch {
+			// change properties of connection
+			old.mu.Lock()
+			defer old.mu.Unlock()
+			u.file, u.r, u.scratch, u.owned = old.file, old.r, old.scratch, old.owned
+			us.table[number] = &u
+			return nil
+		}
+		if err = us.closeUnit(number, ""); err != nil {
+			return
+		}
+	}
+
+	flag := os.O_RDWR
+	switch u.action {
+	case "READ":
+		flag = os.O_RDONLY
+	case "WRITE":
+		flag = os.O_WRONLY
+	}
+	switch st {
+	case "OLD":
+	case "NEW":
+		flag |= os.O_CREATE | os.O_EXCL
+	case "REPLACE":
+		flag |= os.O_CREATE | os.O_TRUNC
+	case "UNKNOWN":
+		flag |= os.O_CREATE
+	}
+	if u.action == "READ" && flag&os.O_CREATE != 0 && st != "NEW" && st != "REPLACE" {
+		// file for reading is not created
+		flag &^= os.O_CREATE
+	}
+
+	var f *os.File
+	if st == "SCRATCH" {
+		f, err = ioutil.TempFile("", "f4go")
+		if err != nil {
+			return
+		}
+		u.name = f.Name()
+		u.scratch = true
+	} else {
+		f, err = os.OpenFile(u.name, flag, 0644)
+		if err != nil && spec.ACTION == nil && os.IsPermission(err) {
+			// default action READWRITE is changed for read-only
+			// and write-only files
+			if f, err = os.OpenFile(u.name, flag&^os.O_RDWR|os.O_RDONLY, 0644); err == nil {
+				u.action = "READ"
+			} else if f, err = os.OpenFile(u.name, flag&^os.O_RDWR|os.O_WRONLY, 0644); err == nil {
+				u.action = "WRITE"
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+	if position == "APPEND" {
+		if _, err = f.Seek(0, io.SeekEnd); err != nil {
+			f.Close()
+			return
+		}
+	}
+	u.file, u.owned = f, true
+	us.table[number] = &u
+	return nil
+}
+
+// CLOSE disconnect unit. Status is KEEP or DELETE, scratch files are
+// deleted always. If iostat is nil, then error is panic.
+//
+// Example:
+//
+//	CLOSE(NOUT, nil, nil)
+//	CLOSE(NOUT, &iostat, []byte("DELETE"))
+func CLOSE(unit int, iostat *int, st []byte) {
+	DefaultUnits.CLOSE(unit, iostat, st)
+}
+
+// CLOSE is function CLOSE for table of units
+func (us *Units) CLOSE(unit int, iostat *int, st []byte) {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	status(iostat, us.closeUnit(unit, string(st)))
+}
+
+func (us *Units) closeUnit(number int, st string) error {
+	u, ok := us.table[number]
+	if !ok {
+		// unit is not connected
+		return nil
+	}
+	def := "KEEP"
+	if u.scratch {
+		def = "DELETE"
+	}
+	st, err := value([]byte(st), def, "KEEP", "DELETE")
+	if err != nil {
+		return err
+	}
+	if st == "KEEP" && u.scratch {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"STATUS='KEEP' is not allowed for scratch file")}
+	}
+	delete(us.table, number)
+	if !u.owned {
+		// streams are closed by owner
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err = u.file.(io.Closer).Close(); err != nil {
+		return err
+	}
+	if st == "DELETE" {
+		return os.Remove(u.name)
+	}
+	return nil
+}
+
+// connected return connection of unit for data transfer with form
+// FORMATTED or UNFORMATTED. Not connected unit is connected to file
+// "fort.N".
+func (us *Units) connected(number int, form string) (*unit, error) {
+	u, ok := us.table[number]
+	if !ok {
+		if err := us.open(number, OpenSpec{FORM: []byte(form)}); err != nil {
+			return nil, err
+		}
+		u = us.table[number]
+	}
+	if u.form != form {
+		return nil, ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"%s I/O on %s unit %d", form, u.form, number)}
+	}
+	return u, nil
+}
@@ -0,0 +1,136 @@
+package intrinsic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := []byte(filepath.Join(dir, "data.txt"))
+	var iostat int
+
+	// file is not exist
+	OPEN(10, &iostat, OpenSpec{FILE: file, STATUS: []byte("OLD")})
+	if iostat != iostatOS {
+		t.Errorf("Not valid status for OLD: %d", iostat)
+	}
+
+	OPEN(10, &iostat, OpenSpec{FILE: file, STATUS: []byte("new ")})
+	if iostat != 0 {
+		t.Fatalf("Cannot open NEW: %d", iostat)
+	}
+	WRITE(10, nil, []byte("(I3)"), 1)
+	CLOSE(10, nil, nil)
+
+	// file is exist
+	OPEN(10, &iostat, OpenSpec{FILE: file, STATUS: []byte("NEW")})
+	if iostat != iostatOS {
+		t.Errorf("Not valid status for NEW: %d", iostat)
+	}
+
+	OPEN(10, nil, OpenSpec{FILE: file, POSITION: []byte("APPEND")})
+	WRITE(10, nil, []byte("(I3)"), 2)
+	CLOSE(10, nil, []byte("KEEP"))
+
+	OPEN(11, nil, OpenSpec{FILE: file, STATUS: []byte("OLD"), ACTION: []byte("READ")})
+	var i, j int
+	READ(11, nil, []byte("(I3)"), &i, &j)
+	if i != 1 || j != 2 {
+		t.Errorf("Not valid values: %d %d", i, j)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Write in file for reading")
+			}
+		}()
+		WRITE(11, nil, []byte("(I3)"), 3)
+	}()
+	WRITE(11, &iostat, []byte("(I3)"), 3)
+	if iostat != iostatOptionConflict {
+		t.Errorf("Not valid status of write in file for reading: %d", iostat)
+	}
+	CLOSE(11, nil, []byte("DELETE"))
+	if _, err := os.Stat(string(file)); !os.IsNotExist(err) {
+		t.Errorf("File is not deleted: %v", err)
+	}
+
+	// file is replaced
+	ioutil.WriteFile(string(file), []byte("old content\n"), 0644)
+	OPEN(12, nil, OpenSpec{FILE: file, STATUS: []byte("REPLACE")})
+	WRITE(12, nil, nil, []byte("new"))
+	CLOSE(12, nil, nil)
+	if b, _ := ioutil.ReadFile(string(file)); string(b) != " new\n" {
+		t.Errorf("Not valid content: %q", b)
+	}
+
+	// scratch file is deleted
+	OPEN(13, nil, OpenSpec{STATUS: []byte("SCRATCH")})
+	name := DefaultUnits.table[13].name
+	WRITE(13, nil, []byte("(I3)"), 4)
+	REWIND(13, nil)
+	READ(13, nil, nil, &i)
+	if i != 4 {
+		t.Errorf("Not valid value in scratch file: %d", i)
+	}
+	CLOSE(13, nil, nil)
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("Scratch file is not deleted: %v", err)
+	}
+
+	// bad specifiers
+	for _, spec := range []OpenSpec{
+		{FILE: file, STATUS: []byte("OPEN")},
+		{FILE: file, ACCESS: []byte("RANDOM")},
+		{FILE: file, FORM: []byte("BINARY")},
+		{FILE: file, BLANK: []byte("ONE")},
+	} {
+		OPEN(14, &iostat, spec)
+		if iostat != iostatBadOption {
+			t.Errorf("Not valid status for %q: %d", spec, iostat)
+		}
+	}
+	for _, spec := range []OpenSpec{
+		{FILE: file, STATUS: []byte("SCRATCH")},
+		{FILE: file, ACCESS: []byte("DIRECT")},
+	} {
+		OPEN(14, &iostat, spec)
+		if iostat != iostatOptionConflict {
+			t.Errorf("Not valid status for %q: %d", spec, iostat)
+		}
+	}
+	CLOSE(14, &iostat, []byte("REMOVE"))
+	if iostat != 0 {
+		t.Errorf("Close of not connected unit: %d", iostat)
+	}
+}
+
+func TestOpenBlank(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "data.txt")
+	ioutil.WriteFile(file, []byte(" 1 \n 1\n"), 0644)
+
+	OPEN(10, nil, OpenSpec{FILE: []byte(file), BLANK: []byte("ZERO"), PAD: []byte("NO")})
+	defer CLOSE(10, nil, nil)
+	var i int
+	READ(10, nil, []byte("(I3)"), &i)
+	if i != 10 {
+		t.Errorf("Not valid value for BLANK='ZERO': %d", i)
+	}
+	var iostat int
+	READ(10, &iostat, []byte("(I3)"), &i)
+	if iostat != iostatEOR {
+		t.Errorf("Not valid status for PAD='NO': %d", iostat)
+	}
+}
@@ -0,0 +1,143 @@
+package intrinsic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// REWIND set position of unit to begin of file.
+// If iostat is nil, then error is panic.
+//
+// Example:
+//
+//	REWIND(NTRA, nil)
+func REWIND(unit int, iostat *int) {
+	DefaultUnits.REWIND(unit, iostat)
+}
+
+// REWIND is function REWIND for table of units
+func (us *Units) REWIND(unit int, iostat *int) {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	status(iostat, us.rewind(unit))
+}
+
+func (us *Units) rewind(number int) error {
+	u, ok := us.table[number]
+	if !ok || u.isStandard() {
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	_, err := u.seek(0, io.SeekStart)
+	return err
+}
+
+// BACKSPACE set position of unit to begin of previous record.
+// If iostat is nil, then error is panic.
+//
+// Example:
+//
+//	BACKSPACE(LFILE, nil)
+func BACKSPACE(unit int, iostat *int) {
+	DefaultUnits.BACKSPACE(unit, iostat)
+}
+
+// BACKSPACE is function BACKSPACE for table of units
+func (us *Units) BACKSPACE(unit int, iostat *int) {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	status(iostat, us.backspace(unit))
+}
+
+func (us *Units) backspace(number int) error {
+	u, ok := us.table[number]
+	if !ok || u.isStandard() {
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.access == "DIRECT" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot BACKSPACE a file opened for DIRECT access")}
+	}
+	pos, err := u.seek(0, io.SeekCurrent)
+	if err != nil || pos == 0 {
+		return err
+	}
+	if u.form == "UNFORMATTED" {
+		// length of previous record is in marker before position
+		marker := make([]byte, 4)
+		if _, err = u.readAt(marker, pos-4); err != nil {
+			return err
+		}
+		pos -= int64(binary.LittleEndian.Uint32(marker)) + 8
+		if pos < 0 {
+			return ioError{code: iostatCorrupt, err: fmt.Errorf(
+				"Unformatted file structure has been corrupted")}
+		}
+		_, err = u.seek(pos, io.SeekStart)
+		return err
+	}
+	// end of line before position is end of previous record
+	c := make([]byte, 1)
+	for pos--; pos > 0; pos-- {
+		if _, err = u.readAt(c, pos-1); err != nil {
+			return err
+		}
+		if c[0] == '\n' {
+			break
+		}
+	}
+	_, err = u.seek(pos, io.SeekStart)
+	return err
+}
+
+// ENDFILE write end of file in position of unit, so rest of file is
+// removed. If iostat is nil, then error is panic.
+//
+// Example:
+//
+//	ENDFILE(NTRA, nil)
+func ENDFILE(unit int, iostat *int) {
+	DefaultUnits.ENDFILE(unit, iostat)
+}
+
+// ENDFILE is function ENDFILE for table of units
+func (us *Units) ENDFILE(unit int, iostat *int) {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	status(iostat, us.endfile(unit))
+}
+
+func (us *Units) endfile(number int) error {
+	u, ok := us.table[number]
+	if !ok {
+		if err := us.open(number, OpenSpec{}); err != nil {
+			return err
+		}
+		u = us.table[number]
+	}
+	if u.isStandard() {
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.access == "DIRECT" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot perform ENDFILE on a file opened for DIRECT access")}
+	}
+	if u.action == "READ" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot perform ENDFILE on a file opened for READ")}
+	}
+	pos, err := u.seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	return u.truncate(pos)
+}
@@ -0,0 +1,96 @@
+package intrinsic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackspace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var i int
+
+	// formatted
+	OPEN(10, nil, OpenSpec{FILE: []byte(filepath.Join(dir, "a.txt"))})
+	WRITE(10, nil, []byte("(I3)"), 1)
+	WRITE(10, nil, []byte("(I3)"), 2)
+	BACKSPACE(10, nil)
+	READ(10, nil, []byte("(I3)"), &i)
+	if i != 2 {
+		t.Errorf("Not valid formatted record after BACKSPACE: %d", i)
+	}
+	BACKSPACE(10, nil)
+	BACKSPACE(10, nil)
+	BACKSPACE(10, nil)
+	READ(10, nil, []byte("(I3)"), &i)
+	if i != 1 {
+		t.Errorf("Not valid first record after BACKSPACE: %d", i)
+	}
+	ENDFILE(10, nil)
+	var iostat int
+	READ(10, &iostat, []byte("(I3)"), &i)
+	if iostat != iostatEnd {
+		t.Errorf("Not valid status after ENDFILE: %d", iostat)
+	}
+	CLOSE(10, nil, nil)
+
+	// unformatted
+	OPEN(11, nil, OpenSpec{FILE: []byte(filepath.Join(dir, "a.bin")), FORM: []byte("UNFORMATTED")})
+	a := []float64{1, 2, 3}
+	WRITEU(11, nil, a)
+	WRITEU(11, nil, &i)
+	BACKSPACE(11, nil)
+	BACKSPACE(11, nil)
+	a[0] = 0
+	READU(11, nil, a)
+	if a[0] != 1 {
+		t.Errorf("Not valid unformatted record after BACKSPACE: %v", a)
+	}
+	CLOSE(11, nil, nil)
+}
+
+func TestBufferedRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "a.txt")
+	if err = ioutil.WriteFile(name, []byte("  1\n  2\n  3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var i int
+
+	// output after input is in position of next record
+	OPEN(10, nil, OpenSpec{FILE: []byte(name)})
+	READ(10, nil, []byte("(I3)"), &i)
+	WRITE(10, nil, []byte("(I3)"), 5)
+	REWIND(10, nil)
+	for _, expect := range []int{1, 5, 3} {
+		READ(10, nil, []byte("(I3)"), &i)
+		if i != expect {
+			t.Errorf("Not valid record after REWIND: %d != %d", i, expect)
+		}
+	}
+	BACKSPACE(10, nil)
+	BACKSPACE(10, nil)
+	READ(10, nil, []byte("(I3)"), &i)
+	if i != 5 {
+		t.Errorf("Not valid record after BACKSPACE: %d", i)
+	}
+	ENDFILE(10, nil)
+	CLOSE(10, nil, nil)
+
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "  1\n  5\n" {
+		t.Errorf("Not valid file after ENDFILE: %q", b)
+	}
+}
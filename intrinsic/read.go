@@ -0,0 +1,514 @@
+package intrinsic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// status codes of input/output statements, same as in gfortran
+const (
+	iostatEOR       = -2
+	iostatEnd       = -1
+	iostatOS        = 5000
+	iostatFormat    = 5006
+	iostatReadValue = 5010
+)
+
+// ioError is error of input/output statement with status code
+type ioError struct {
+	code int
+	err  error
+}
+
+func (e ioError) Error() string {
+	return e.err.Error()
+}
+
+// iostatOf return status code of error
+func iostatOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	if err == io.EOF {
+		return iostatEnd
+	}
+	if e, ok := err.(ioError); ok {
+		return e.code
+	}
+	return iostatOS
+}
+
+// status store status code of error in iostat. If iostat is nil,
+// then error is panic.
+func status(iostat *int, err error) {
+	if iostat != nil {
+		*iostat = iostatOf(err)
+		return
+	}
+	if err == io.EOF {
+		panic(fmt.Errorf("End of file"))
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// READ is input of items from unit by format.
+// Format nil is list-directed input. Unit is number of unit, Record
+// of direct access file or CHARACTER variable of internal file.
+// Items are pointers to variables or slices.
+//
+// If iostat is nil, then error of input is panic. Otherwise status of
+// input is stored in iostat:
+//   - zero, if no errors;
+//   - negative, if end of file;
+//   - positive, if error.
+//
+// Example:
+//
+//	READ(5, nil, []byte("(I5,F10.2)"), &N, &X)
+//	READ(5, &iostat, nil, A)
+func READ(unit interface{}, iostat *int, format []byte, a ...interface{}) {
+	DefaultUnits.READ(unit, iostat, format, a...)
+}
+
+// READ is function READ for table of units
+func (us *Units) READ(unit interface{}, iostat *int, format []byte, a ...interface{}) {
+	us = us.get()
+	status(iostat, us.read(unit, format, a))
+}
+
+func (us *Units) read(unit interface{}, format []byte, a []interface{}) error {
+	if records, ok := internal(unit); ok {
+		next := internalRecords(records)
+		if format == nil {
+			return readList(next, a)
+		}
+		return formatRead(format, next, a, 'N', true)
+	}
+	u, rec, err := us.lockedConnection(unit, "FORMATTED")
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	if u.action == "WRITE" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot read from file opened for WRITE")}
+	}
+	if rec != 0 {
+		if format == nil {
+			return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+				"List-directed input is not allowed for direct access")}
+		}
+		return formatRead(format, u.readDirect(rec), a, u.blank, u.pad)
+	}
+	next := readRecord(u.reader())
+	if format == nil {
+		return readList(next, a)
+	}
+	return formatRead(format, next, a, u.blank, u.pad)
+}
+
+// readList is list-directed input with status codes of errors
+func readList(next func() ([]byte, error), a []interface{}) error {
+	err := listRead(next, a)
+	if _, ok := err.(ioError); !ok && err != nil && err != io.EOF {
+		err = ioError{code: iostatReadValue, err: err}
+	}
+	return err
+}
+
+// readRecord return function for reading next record without end of line
+func readRecord(r io.Reader) func() ([]byte, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return func() ([]byte, error) {
+		record, err := br.ReadBytes('\n')
+		if err == nil {
+			return record[:len(record)-1], nil
+		}
+		if err == io.EOF && len(record) > 0 {
+			return record, nil
+		}
+		return nil, err
+	}
+}
+
+// target is item of formatted input
+type target struct {
+	v reflect.Value
+	// part of complex value: 0 - all value, 1 - real, 2 - imaginary
+	part int
+}
+
+// formatReader is reader of items from records by format
+type formatReader struct {
+	next    func() ([]byte, error)
+	targets []target
+	record  []byte
+	pos     int
+	scale   int
+	// blank is 'Z', if blanks in numeric fields are zeros
+	blank byte
+	// pad is true, if record is padded by blanks
+	pad bool
+	// short is true, if field is out of record
+	short bool
+	// amount of transferred items
+	transferred int
+}
+
+// formatRead read items from records by format.
+//
+// Rules:
+//   - fields have width of edit descriptor, record is padded by blanks;
+//   - comma terminates numeric field;
+//   - blanks in numeric fields are ignored, or zeros after BZ;
+//   - reals without decimal point have d digits after point;
+//   - reals without exponent are divided by 10**k for scale factor kP;
+//   - rest of last record is skipped.
+func formatRead(format []byte, next func() ([]byte, error), a []interface{},
+	blank byte, pad bool) error {
+	list, err := parseFormat(format)
+	if err != nil {
+		return ioError{code: iostatFormat, err: err}
+	}
+	r := formatReader{next: next, blank: blank, pad: pad}
+	for i := range a {
+		elements(reflect.ValueOf(a[i]), func(v reflect.Value) {
+			if !v.CanSet() && !(isCharacter(v) && v.Kind() == reflect.Slice) {
+				err = fmt.Errorf("item %d in formatted input is not variable",
+					len(r.targets)+1)
+			}
+			switch v.Kind() {
+			case reflect.Complex64, reflect.Complex128:
+				r.targets = append(r.targets, target{v: v, part: 1}, target{v: v, part: 2})
+			default:
+				r.targets = append(r.targets, target{v: v})
+			}
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if err := r.nextRecord(); err != nil {
+		return err
+	}
+	revert := revertPosition(list)
+	for pass := list; ; pass = list[revert:] {
+		transferred := r.transferred
+		stop, err := r.exec(pass)
+		if err != nil {
+			return err
+		}
+		if stop || len(r.targets) == 0 {
+			return nil
+		}
+		if transferred == r.transferred {
+			return ioError{code: iostatFormat, err: fmt.Errorf(
+				"format without data edit descriptors for %d items", len(r.targets))}
+		}
+		if err := r.nextRecord(); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *formatReader) nextRecord() (err error) {
+	r.record, err = r.next()
+	r.pos = 0
+	return
+}
+
+// exec execute items of format. Result stop is true, if format
+// control is terminated because items are finished.
+func (r *formatReader) exec(list []formatItem) (stop bool, err error) {
+	for _, it := range list {
+		switch it.kind {
+		case '(':
+			for i := 0; i < it.repeat; i++ {
+				if stop, err = r.exec(it.group); stop || err != nil {
+					return
+				}
+			}
+		case '\'':
+			return false, ioError{code: iostatFormat, err: fmt.Errorf(
+				"Constant not allowed in format for input: %q", it.text)}
+		case 'X':
+			r.pos += it.w
+		case 'T':
+			switch it.sub {
+			case 'L':
+				r.pos -= it.w
+				if r.pos < 0 {
+					r.pos = 0
+				}
+			case 'R':
+				r.pos += it.w
+			default:
+				r.pos = it.w - 1
+				if r.pos < 0 {
+					r.pos = 0
+				}
+			}
+		case '/':
+			for i := 0; i < it.repeat; i++ {
+				if err = r.nextRecord(); err != nil {
+					return
+				}
+			}
+		case ':':
+			if len(r.targets) == 0 {
+				return true, nil
+			}
+		case 'P':
+			r.scale = it.w
+		case 'N':
+			r.blank = it.sub
+		case 'S', '$':
+			// no effect on input
+		default:
+			for i := 0; i < it.repeat; i++ {
+				if len(r.targets) == 0 {
+					return true, nil
+				}
+				t := r.targets[0]
+				r.targets = r.targets[1:]
+				r.transferred++
+				if err = r.edit(it, t); err != nil {
+					return
+				}
+				if r.short && !r.pad {
+					return false, ioError{code: iostatEOR, err: fmt.Errorf("End of record")}
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// field return next field of record with width w. If comma is true,
+// then field is terminated by comma.
+func (r *formatReader) field(w int, comma bool) []byte {
+	b := make([]byte, 0, w)
+	for i := 0; i < w; i++ {
+		c := byte(' ')
+		if r.pos < len(r.record) {
+			c = r.record[r.pos]
+		} else {
+			r.short = true
+		}
+		r.pos++
+		if c == ',' && comma {
+			break
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+// numeric return value of numeric field without blanks
+func (r *formatReader) numeric(w int) []byte {
+	b := bytes.TrimLeft(r.field(w, true), " ")
+	if r.blank == 'Z' {
+		return bytes.Replace(b, []byte{' '}, []byte{'0'}, -1)
+	}
+	return bytes.Replace(b, []byte{' '}, nil, -1)
+}
+
+// edit read value of target by data edit descriptor
+func (r *formatReader) edit(it formatItem, t target) error {
+	v := t.v
+	kind := it.kind
+	if kind == 'G' {
+		// G editing of not real values
+		switch {
+		case isCharacter(v):
+			kind = 'A'
+		case v.Kind() == reflect.Bool:
+			kind = 'L'
+		case isInteger(v):
+			kind, it.d = 'I', -1
+		}
+	}
+	switch kind {
+	case 'I', 'B', 'O', 'Z':
+		if !isInteger(v) {
+			break
+		}
+		w := it.w
+		if w < 0 {
+			w = defaultIntegerWidth(integerBits(v))
+		}
+		base := map[byte]int{'I': 10, 'B': 2, 'O': 8, 'Z': 16}[kind]
+		b := r.numeric(w)
+		if len(b) == 0 {
+			v.SetInt(0)
+			return nil
+		}
+		i, err := strconv.ParseInt(string(b), base, integerBits(v))
+		if err != nil && kind != 'I' {
+			// bit pattern of negative values
+			var u uint64
+			u, err = strconv.ParseUint(string(b), base, integerBits(v))
+			i = int64(u)
+		}
+		if err != nil {
+			return r.errorf("Bad value during integer read %q", b)
+		}
+		v.SetInt(i)
+		return nil
+
+	case 'F', 'E', 'D', 'G':
+		if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 && t.part == 0 {
+			break
+		}
+		w, d := it.w, it.d
+		if w < 0 {
+			w, d, _ = defaultRealWidth(64)
+		}
+		b := r.numeric(w)
+		x, err := readReal(b, d, r.scale)
+		if err != nil {
+			return r.errorf("Bad value during floating point read %q", b)
+		}
+		switch t.part {
+		case 1:
+			v.SetComplex(complex(x, imag(v.Complex())))
+		case 2:
+			v.SetComplex(complex(real(v.Complex()), x))
+		default:
+			v.SetFloat(x)
+		}
+		return nil
+
+	case 'L':
+		if v.Kind() != reflect.Bool {
+			break
+		}
+		w := it.w
+		if w < 0 {
+			w = 2
+		}
+		b := bytes.TrimLeft(r.field(w, true), " ")
+		b = bytes.TrimPrefix(b, []byte{'.'})
+		if len(b) == 0 {
+			return r.errorf("Bad logical value")
+		}
+		switch b[0] {
+		case 'T', 't':
+			v.SetBool(true)
+		case 'F', 'f':
+			v.SetBool(false)
+		default:
+			return r.errorf("Bad logical value %q", b)
+		}
+		return nil
+
+	case 'A':
+		if !isCharacter(v) {
+			break
+		}
+		w := it.w
+		if w < 0 {
+			w = v.Len()
+		}
+		b := r.field(w, false)
+		if w > v.Len() {
+			// rightmost characters
+			b = b[w-v.Len():]
+		}
+		setCharacter(v, b)
+		return nil
+	}
+	name := typeName(scalar(v))
+	if t.part > 0 {
+		name = "REAL"
+	}
+	return ioError{code: iostatFormat, err: fmt.Errorf(
+		"Expected %s for item %d in formatted transfer, got %s",
+		expectedType(kind), r.transferred, name)}
+}
+
+func (r *formatReader) errorf(format string, a ...interface{}) error {
+	return ioError{code: iostatReadValue, err: fmt.Errorf("%s for item %d",
+		fmt.Sprintf(format, a...), r.transferred)}
+}
+
+// integerBits return size of integer in bits.
+// Type int is INTEGER of fortran with size 32 bits.
+func integerBits(v reflect.Value) int {
+	if v.Kind() == reflect.Int {
+		return 32
+	}
+	return v.Type().Bits()
+}
+
+func isInteger(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// readReal return value of real field without blanks. If mantissa
+// have not decimal point, then mantissa have d digits after point.
+// If field have not exponent, then value is divided by 10**k.
+//
+// Example for F10.2:
+//
+//	1.5     is 1.5
+//	150     is 1.5
+//	15E1    is 1.5
+//	1.5D+2  is 150.0
+//	1.5-2   is 0.015
+func readReal(b []byte, d, k int) (float64, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if bytes.ContainsAny(bytes.ToUpper(b), "AINF") {
+		// Infinity and NaN
+		x, err := strconv.ParseFloat(string(b), 64)
+		if err != nil || !math.IsInf(x, 0) && !math.IsNaN(x) {
+			return 0, fmt.Errorf("Bad real %q", b)
+		}
+		return x, nil
+	}
+
+	mantissa, exp, hasExp := b, 0, false
+	for i := 1; i < len(b); i++ {
+		var e []byte
+		switch b[i] {
+		case 'E', 'e', 'D', 'd', 'Q', 'q':
+			e = b[i+1:]
+		case '+', '-':
+			e = b[i:]
+		default:
+			continue
+		}
+		ex, err := strconv.Atoi(string(e))
+		if err != nil {
+			return 0, err
+		}
+		mantissa, exp, hasExp = b[:i], ex, true
+		break
+	}
+	if bytes.IndexByte(mantissa, '.') < 0 && d > 0 {
+		exp -= d
+	}
+	if !hasExp {
+		exp -= k
+	}
+	if len(bytes.Trim(mantissa, "+-.")) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseFloat(string(mantissa)+"e"+strconv.Itoa(exp), 64)
+}
@@ -0,0 +1,109 @@
+package intrinsic
+
+import (
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormatRead(t *testing.T) {
+	var (
+		i, j int
+		x, y float64
+		c    complex128
+		b    bool
+		s    = []byte("12345")
+		a    = []int{0, 0}
+		r    = []float64{0, 0}
+	)
+	tcs := []struct {
+		format string
+		input  string
+		items  []interface{}
+		check  func() bool
+	}{
+		{"(I5)", "   42", []interface{}{&i}, func() bool { return i == 42 }},
+		{"(I3,I3)", " 1 2 3", []interface{}{&i, &j}, func() bool { return i == 1 && j == 23 }},
+		{"(BZ,I3,BN,I3)", " 1 2 3", []interface{}{&i, &j}, func() bool { return i == 10 && j == 23 }},
+		{"(2I5)", "1,2", []interface{}{&i, &j}, func() bool { return i == 1 && j == 2 }},
+		{"(I5)", "\n", []interface{}{&i}, func() bool { return i == 0 }},
+		{"(Z4,O3)", "  ff 17", []interface{}{&i, &j}, func() bool { return i == 255 && j == 15 }},
+		{"(2X,I2)", "1234", []interface{}{&i}, func() bool { return i == 34 }},
+		{"(T3,I2,TL4,I2)", "1234", []interface{}{&i, &j}, func() bool { return i == 34 && j == 12 }},
+		{"(F10.2)", "       150", []interface{}{&x}, func() bool { return x == 1.5 }},
+		{"(F10.2)", "      1.25", []interface{}{&x}, func() bool { return x == 1.25 }},
+		{"(E10.2)", "     15E+1", []interface{}{&x}, func() bool { return x == 1.5 }},
+		{"(D10.2)", "   1.5D+02", []interface{}{&x}, func() bool { return x == 150 }},
+		{"(F10.2)", "     1.5-2", []interface{}{&x}, func() bool { return x == 0.015 }},
+		{"(2P,F10.2)", "      1.25", []interface{}{&x}, func() bool { return x == 0.0125 }},
+		{"(2P,F10.2)", "    1.25E1", []interface{}{&x}, func() bool { return x == 12.5 }},
+		{"(F5.1,F5.1)", "  1.5 -2.5", []interface{}{&c}, func() bool { return c == complex(1.5, -2.5) }},
+		{"(G10.3,G3.1)", "      3.25 12", []interface{}{&x, &i}, func() bool { return x == 3.25 && i == 12 }},
+		{"(F10.2)", "  Infinity", []interface{}{&x}, func() bool { return math.IsInf(x, 1) }},
+		{"(L4,L3)", " .TR  F", []interface{}{&b, &b}, func() bool { return !b }},
+		{"(A)", "ab", []interface{}{&s}, func() bool { return string(s) == "ab   " }},
+		{"(A3)", "abcdef", []interface{}{s}, func() bool { return string(s) == "abc  " }},
+		{"(A7)", "abcdefg", []interface{}{s}, func() bool { return string(s) == "cdefg" }},
+		{"(I2/I2)", " 1\n 2", []interface{}{&i, &j}, func() bool { return i == 1 && j == 2 }},
+		{"(I2)", " 1 2\n 3", []interface{}{&i, &j}, func() bool { return i == 1 && j == 3 }},
+		{"(3I2)", " 1 2 3", []interface{}{a}, func() bool { return a[0] == 1 && a[1] == 2 }},
+		{"(F5.1)", "  1.5\n  2.5", []interface{}{&r}, func() bool { return r[0] == 1.5 && r[1] == 2.5 }},
+		{"(2I2)", " 1 2", []interface{}{func() (items []interface{}) {
+			return append(items, &i, &j)
+		}()}, func() bool { return i == 1 && j == 2 }},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			i, j, x, y, c, b = -1, -1, -1, -1, -1, true
+			next := readRecord(strings.NewReader(tc.input))
+			if err := formatRead([]byte(tc.format), next, tc.items, 'N', true); err != nil {
+				t.Fatal(err)
+			}
+			if !tc.check() {
+				t.Errorf("Not valid: %v %v %v %v %v %v %q", i, j, x, y, c, b, s)
+			}
+		})
+	}
+}
+
+func TestFormatReadFail(t *testing.T) {
+	tcs := []struct {
+		format string
+		input  string
+		item   interface{}
+		code   int
+	}{
+		{"(I5)", "  1.5", new(int), iostatReadValue},
+		{"(F5.1)", "  1.x", new(float64), iostatReadValue},
+		{"(L2)", " X", new(bool), iostatReadValue},
+		{"(F5.1)", "  1.5", new(int), iostatFormat},
+		{"(I5", "  1", new(int), iostatFormat},
+		{"('a',I2)", "  1", new(int), iostatFormat},
+		{"(I5)", "", new(int), iostatEnd},
+		{"(I5/I5)", "1", []int{0, 0}, iostatEnd},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			next := readRecord(strings.NewReader(tc.input))
+			err := formatRead([]byte(tc.format), next, []interface{}{tc.item}, 'N', true)
+			if code := iostatOf(err); code != tc.code {
+				t.Errorf("Not valid status %d: %v", code, err)
+			}
+		})
+	}
+}
+
+func TestReadStatus(t *testing.T) {
+	var iostat int
+	status(&iostat, io.EOF)
+	if iostat != iostatEnd {
+		t.Errorf("Not valid end of file: %d", iostat)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Not panic")
+		}
+	}()
+	status(nil, io.EOF)
+}
@@ -0,0 +1,267 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// System, time and command-line intrinsic procedures. Values of
+// CHARACTER arguments are changed in place, values are truncated or
+// padded with blanks. Absent optional arguments are nil.
+//
+//	CALL GETARG(1, ARG)             - intrinsic.GETARG(1, (*ARG))
+//	CALL SYSTEM_CLOCK(COUNT=C)      - intrinsic.SYSTEM_CLOCK[int](C, nil, nil)
+//	CALL GET_COMMAND(CMD, STATUS=S) - intrinsic.GET_COMMAND[int]((*CMD), nil, S)
+
+// start is time of start of program
+var start = time.Now()
+
+// exit is termination of program, it is changed in tests
+var exit = os.Exit
+
+// set store value in pointer, if pointer is not nil
+func set[T any](p *T, v T) {
+	if p != nil {
+		*p = v
+	}
+}
+
+// fill copy s to value and return status: -1 for truncated value,
+// otherwise 0
+func fill(value []byte, s string) int {
+	Character(value).Set([]byte(s))
+	if value != nil && len(s) > len(value) {
+		return -1
+	}
+	return 0
+}
+
+// IARGC return amount of command-line arguments
+func IARGC() int { return len(os.Args) - 1 }
+
+// COMMAND_ARGUMENT_COUNT return amount of command-line arguments
+func COMMAND_ARGUMENT_COUNT() int { return len(os.Args) - 1 }
+
+// GETARG copy command-line argument n to value. Argument 0 is name of
+// program, value of not existed argument is blank.
+func GETARG(n int, value []byte) {
+	if n < 0 || len(os.Args) <= n {
+		fill(value, "")
+		return
+	}
+	fill(value, os.Args[n])
+}
+
+// GET_COMMAND copy command line to command. Status is -1 for
+// truncated command.
+func GET_COMMAND[T Integer](command []byte, length, status *T) {
+	cmd := strings.Join(os.Args, " ")
+	set(length, T(len(cmd)))
+	set(status, T(fill(command, cmd)))
+}
+
+// GET_COMMAND_ARGUMENT copy command-line argument number to value.
+// Status is 1 for not existed argument and -1 for truncated value.
+func GET_COMMAND_ARGUMENT[T Integer](number int, value []byte, length, status *T) {
+	if number < 0 || len(os.Args) <= number {
+		fill(value, "")
+		set(length, 0)
+		set(status, 1)
+		return
+	}
+	set(length, T(len(os.Args[number])))
+	set(status, T(fill(value, os.Args[number])))
+}
+
+// GETENV copy value of environment variable to value. Trailing blanks
+// of name are ignored.
+func GETENV(name, value []byte) {
+	fill(value, os.Getenv(string(bytes.TrimRight(name, " "))))
+}
+
+// GET_ENVIRONMENT_VARIABLE copy value of environment variable to
+// value. Trailing blanks of name are ignored, if trimName is absent or
+// true. Status is 1 for not existed variable and -1 for truncated
+// value.
+func GET_ENVIRONMENT_VARIABLE[T Integer](name, value []byte, length, status *T, trimName ...bool) {
+	if len(trimName) == 0 || trimName[0] {
+		name = bytes.TrimRight(name, " ")
+	}
+	v, ok := os.LookupEnv(string(name))
+	if !ok {
+		fill(value, "")
+		set(length, 0)
+		set(status, 1)
+		return
+	}
+	set(length, T(len(v)))
+	set(status, T(fill(value, v)))
+}
+
+// CPU_TIME store processor time in seconds
+func CPU_TIME(t *float64) {
+	user, system := cpuTime()
+	*t = user + system
+}
+
+// SYSTEM_CLOCK store count of clock from start of program, count per
+// second and maximal count. Clock of INTEGER with size 32 bits or less
+// has resolution of millisecond, otherwise microsecond.
+func SYSTEM_CLOCK[T Integer](count, rate, max *T) {
+	var x T
+	r, m := int64(1000000), int64(HUGE(x))
+	if DIGITS(x) <= 31 {
+		r = 1000
+	}
+	c := time.Since(start).Microseconds() * r / 1000000
+	if m < math.MaxInt64 {
+		c %= m + 1
+	}
+	set(count, T(c))
+	set(rate, T(r))
+	set(max, T(m))
+}
+
+// DATE_AND_TIME store local date as CCYYMMDD, time as hhmmss.sss, zone
+// as +hhmm and values: year, month, day, difference with UTC in
+// minutes, hour, minutes, seconds and milliseconds.
+func DATE_AND_TIME[T Integer](date, clock, zone []byte, values []T) {
+	now := time.Now()
+	_, offset := now.Zone()
+	fill(date, now.Format("20060102"))
+	fill(clock, now.Format("150405.000"))
+	fill(zone, now.Format("-0700"))
+	for i, v := range []int{now.Year(), int(now.Month()), now.Day(), offset / 60,
+		now.Hour(), now.Minute(), now.Second(), now.Nanosecond() / 1000000} {
+		if i < len(values) {
+			values[i] = T(v)
+		}
+	}
+}
+
+// dtime is processor time of last call DTIME
+var dtime struct {
+	mu           sync.Mutex
+	user, system float64
+}
+
+// ETIME store user and system processor time in tarray and return
+// sum of them. Result is stored in result for subroutine ETIME.
+func ETIME(tarray []float64, result ...*float64) float64 {
+	user, system := cpuTime()
+	return elapsed(tarray, result, user, system)
+}
+
+// DTIME store user and system processor time from last call of DTIME
+// in tarray and return sum of them. Result is stored in result for
+// subroutine DTIME.
+func DTIME(tarray []float64, result ...*float64) float64 {
+	user, system := cpuTime()
+	dtime.mu.Lock()
+	defer dtime.mu.Unlock()
+	user, dtime.user = user-dtime.user, user
+	system, dtime.system = system-dtime.system, system
+	return elapsed(tarray, result, user, system)
+}
+
+// elapsed store processor time in tarray and result
+func elapsed(tarray []float64, result []*float64, user, system float64) float64 {
+	copy(tarray, []float64{user, system})
+	for _, r := range result {
+		set(r, user+system)
+	}
+	return user + system
+}
+
+// SYSTEM run command by shell and return exit status of command.
+// Status is stored in status for subroutine SYSTEM.
+func SYSTEM(command []byte, status ...*int) int {
+	cmd := exec.Command("sh", "-c", string(bytes.TrimRight(command, " ")))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	code := 0
+	if err := cmd.Run(); err != nil {
+		code = 127
+		if e, ok := err.(*exec.ExitError); ok {
+			code = e.ExitCode()
+		}
+	}
+	for _, s := range status {
+		set(s, code)
+	}
+	return code
+}
+
+// EXIT terminate program with status, default status is 0
+func EXIT(status ...int) {
+	code := 0
+	if len(status) > 0 {
+		code = status[0]
+	}
+	terminate(code)
+}
+
+// STOP terminate program with code, default code is 0. Code is written
+// to standard error.
+//
+//	STOP    - intrinsic.STOP()
+//	STOP 3  - intrinsic.STOP(3)
+func STOP(code ...int) {
+	if len(code) == 0 {
+		terminate(0)
+		return
+	}
+	fmt.Fprintln(os.Stderr, "STOP", code[0])
+	terminate(code[0])
+}
+
+// STOP_MESSAGE terminate program with code 0. Message is written to
+// standard error.
+//
+//	STOP 'END' - intrinsic.STOP_MESSAGE([]byte("END"))
+func STOP_MESSAGE(message []byte) {
+	fmt.Fprintln(os.Stderr, "STOP", string(message))
+	terminate(0)
+}
+
+// ERROR_STOP terminate program with error code, default code is 1.
+// Code is written to standard error.
+//
+//	ERROR STOP   - intrinsic.ERROR_STOP()
+//	ERROR STOP 2 - intrinsic.ERROR_STOP(2)
+func ERROR_STOP(code ...int) {
+	if len(code) == 0 {
+		fmt.Fprintln(os.Stderr, "ERROR STOP")
+		terminate(1)
+		return
+	}
+	fmt.Fprintln(os.Stderr, "ERROR STOP", code[0])
+	terminate(code[0])
+}
+
+// ERROR_STOP_MESSAGE terminate program with error code 1. Message is
+// written to standard error.
+//
+//	ERROR STOP 'FAIL' - intrinsic.ERROR_STOP_MESSAGE([]byte("FAIL"))
+func ERROR_STOP_MESSAGE(message []byte) {
+	fmt.Fprintln(os.Stderr, "ERROR STOP", string(message))
+	terminate(1)
+}
+
+// terminate close files of DefaultUnits and exit with code
+func terminate(code int) {
+	us := DefaultUnits
+	us.mu.Lock()
+	for number, u := range us.table {
+		if u.owned {
+			_ = us.closeUnit(number, "")
+		}
+	}
+	us.mu.Unlock()
+	exit(code)
+}
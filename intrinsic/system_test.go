@@ -0,0 +1,134 @@
+package intrinsic
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCommandLine(t *testing.T) {
+	args := os.Args
+	defer func() { os.Args = args }()
+	os.Args = []string{"prog", "-iINPUT", "-o"}
+
+	if n := IARGC(); n != 2 {
+		t.Errorf("Not valid IARGC: %d", n)
+	}
+	if n := COMMAND_ARGUMENT_COUNT(); n != 2 {
+		t.Errorf("Not valid COMMAND_ARGUMENT_COUNT: %d", n)
+	}
+	arg := *NewCharacter(4)
+	GETARG(2, arg)
+	if string(arg) != "-o  " {
+		t.Errorf("Not valid GETARG: %q", arg)
+	}
+	GETARG(3, arg)
+	if string(arg) != "    " {
+		t.Errorf("Not valid GETARG of not existed argument: %q", arg)
+	}
+	var length, status int
+	GET_COMMAND_ARGUMENT(1, arg, &length, &status)
+	if string(arg) != "-iIN" || length != 7 || status != -1 {
+		t.Errorf("Not valid truncated argument: %q %d %d", arg, length, status)
+	}
+	var length4, status4 int32
+	GET_COMMAND_ARGUMENT(5, arg, &length4, &status4)
+	if length4 != 0 || status4 != 1 {
+		t.Errorf("Not valid status of not existed argument: %d %d", length4, status4)
+	}
+	cmd := *NewCharacter(20)
+	GET_COMMAND(cmd, &length, &status)
+	if string(cmd) != "prog -iINPUT -o     " || length != 15 || status != 0 {
+		t.Errorf("Not valid GET_COMMAND: %q %d %d", cmd, length, status)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	os.Setenv("F4GO_TEST", "value")
+	defer os.Unsetenv("F4GO_TEST")
+	v := *NewCharacter(8)
+	GETENV([]byte("F4GO_TEST  "), v)
+	if string(v) != "value   " {
+		t.Errorf("Not valid GETENV: %q", v)
+	}
+	var length, status int
+	GET_ENVIRONMENT_VARIABLE([]byte("F4GO_TEST "), v, &length, &status, false)
+	if string(v) != "        " || status != 1 {
+		t.Errorf("Not valid variable with blanks: %q %d", v, status)
+	}
+	GET_ENVIRONMENT_VARIABLE([]byte("F4GO_TEST"), v[:3], &length, &status)
+	if string(v) != "val     " || length != 5 || status != -1 {
+		t.Errorf("Not valid truncated variable: %q %d %d", v, length, status)
+	}
+}
+
+func TestClock(t *testing.T) {
+	var count, rate, max int32
+	SYSTEM_CLOCK(&count, &rate, &max)
+	if count < 0 || rate != 1000 || max != 2147483647 {
+		t.Errorf("Not valid SYSTEM_CLOCK of INTEGER*4: %d %d %d", count, rate, max)
+	}
+	var count8, rate8 int64
+	SYSTEM_CLOCK(&count8, &rate8, nil)
+	if count8 < 0 || rate8 != 1000000 {
+		t.Errorf("Not valid SYSTEM_CLOCK: %d %d", count8, rate8)
+	}
+
+	date, clock, zone := *NewCharacter(8), *NewCharacter(10), *NewCharacter(5)
+	values := make([]int, 8)
+	DATE_AND_TIME(date, clock, zone, values)
+	if values[0] < 2000 || values[1] < 1 || 12 < values[1] || clock[6] != '.' ||
+		(zone[0] != '+' && zone[0] != '-') {
+		t.Errorf("Not valid DATE_AND_TIME: %q %q %q %v", date, clock, zone, values)
+	}
+
+	var cpu, result float64
+	CPU_TIME(&cpu)
+	tarray := make([]float64, 2)
+	if e := ETIME(tarray, &result); cpu < 0 || e < 0 || e != tarray[0]+tarray[1] || e != result {
+		t.Errorf("Not valid processor time: %v %v %v %v", cpu, e, tarray, result)
+	}
+	if e := DTIME(tarray); e < 0 || e != tarray[0]+tarray[1] {
+		t.Errorf("Not valid DTIME: %v %v", e, tarray)
+	}
+}
+
+func TestSystem(t *testing.T) {
+	var status int
+	if code := SYSTEM([]byte("exit 3  "), &status); code != 3 || status != 3 {
+		t.Errorf("Not valid status of SYSTEM: %d %d", code, status)
+	}
+}
+
+func TestStop(t *testing.T) {
+	defer func() { exit = os.Exit }()
+	var code int
+	exit = func(c int) { code = c }
+
+	name := t.TempDir() + "/stop.txt"
+	OPEN(10, nil, OpenSpec{FILE: []byte(name)})
+	STOP(3)
+	if code != 3 {
+		t.Errorf("Not valid code of STOP: %d", code)
+	}
+	if _, ok := DefaultUnits.table[10]; ok {
+		t.Errorf("Unit is not closed by STOP")
+	}
+	for _, tc := range []struct {
+		name string
+		stop func()
+		code int
+	}{
+		{"STOP", func() { STOP() }, 0},
+		{"STOP 'END'", func() { STOP_MESSAGE([]byte("END")) }, 0},
+		{"ERROR STOP", func() { ERROR_STOP() }, 1},
+		{"ERROR STOP 2", func() { ERROR_STOP(2) }, 2},
+		{"ERROR STOP 'FAIL'", func() { ERROR_STOP_MESSAGE([]byte("FAIL")) }, 1},
+		{"EXIT", func() { EXIT() }, 0},
+	} {
+		code = -1
+		tc.stop()
+		if code != tc.code {
+			t.Errorf("Not valid code of %s: %d", tc.name, code)
+		}
+	}
+}
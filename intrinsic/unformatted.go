@@ -0,0 +1,257 @@
+package intrinsic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// status codes of unformatted input, same as in gfortran
+const (
+	iostatShortRecord = 5016
+	iostatCorrupt     = 5017
+)
+
+// WRITEU is unformatted output of items in unit as one record.
+// Record is written in layout of gfortran: length of record as 4 byte
+// marker, data of items, marker again. All values are little-endian.
+// If iostat is nil, then error of output is panic.
+//
+// Sizes of values:
+//   - int, LOGICAL (bool) - 4 bytes;
+//   - int8, int16, int64 - by type;
+//   - float32, float64 - by type, complex is pair of reals;
+//   - CHARACTER - bytes without change.
+//
+// Records of direct access file are without markers and padded by
+// zeros to length RECL.
+//
+// Example:
+//
+//	WRITEU(IOS, nil, &NSTEP, &TTIM, U)
+//	WRITEU(Record{Unit: 10, REC: K}, &iostat, A)
+func WRITEU(unit interface{}, iostat *int, a ...interface{}) {
+	DefaultUnits.WRITEU(unit, iostat, a...)
+}
+
+// WRITEU is function WRITEU for table of units
+func (us *Units) WRITEU(unit interface{}, iostat *int, a ...interface{}) {
+	us = us.get()
+	status(iostat, us.writeu(unit, a))
+}
+
+func (us *Units) writeu(unit interface{}, a []interface{}) error {
+	u, rec, err := us.lockedConnection(unit, "UNFORMATTED")
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	if err := u.writable(); err != nil {
+		return err
+	}
+	if rec != 0 {
+		return u.writeDirect(rec, [][]byte{unformattedData(a)}, 0)
+	}
+	err = u.write(unformattedRecord(a))
+	return err
+}
+
+// unformattedData return values of items without markers
+func unformattedData(a []interface{}) []byte {
+	var data bytes.Buffer
+	for i := range a {
+		elements(reflect.ValueOf(a[i]), func(v reflect.Value) {
+			encode(&data, v)
+		})
+	}
+	return data.Bytes()
+}
+
+// unformattedRecord return record of items with markers
+func unformattedRecord(a []interface{}) []byte {
+	data := unformattedData(a)
+	marker := make([]byte, 4)
+	binary.LittleEndian.PutUint32(marker, uint32(len(data)))
+	record := make([]byte, 0, len(data)+8)
+	record = append(record, marker...)
+	record = append(record, data...)
+	return append(record, marker...)
+}
+
+func encode(buf *bytes.Buffer, v reflect.Value) {
+	if isCharacter(v) {
+		buf.Write(characterBytes(v))
+		return
+	}
+	var b [8]byte
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			b[0] = 1
+		}
+		buf.Write(b[:4])
+	case reflect.Int, reflect.Int32:
+		binary.LittleEndian.PutUint32(b[:], uint32(v.Int()))
+		buf.Write(b[:4])
+	case reflect.Int8:
+		buf.WriteByte(byte(v.Int()))
+	case reflect.Int16:
+		binary.LittleEndian.PutUint16(b[:], uint16(v.Int()))
+		buf.Write(b[:2])
+	case reflect.Int64:
+		binary.LittleEndian.PutUint64(b[:], uint64(v.Int()))
+		buf.Write(b[:])
+	case reflect.Float32:
+		binary.LittleEndian.PutUint32(b[:], math.Float32bits(float32(v.Float())))
+		buf.Write(b[:4])
+	case reflect.Float64:
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v.Float()))
+		buf.Write(b[:])
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		size := v.Type().Size() * 4 // bits of one part
+		encode(buf, reflect.ValueOf(real(c)).Convert(floatType(size)))
+		encode(buf, reflect.ValueOf(imag(c)).Convert(floatType(size)))
+	default:
+		panic(fmt.Errorf("Type %v is not supported in unformatted output", v.Type()))
+	}
+}
+
+func floatType(bits uintptr) reflect.Type {
+	if bits == 32 {
+		return reflect.TypeOf(float32(0))
+	}
+	return reflect.TypeOf(float64(0))
+}
+
+// READU is unformatted input of items from one record of unit.
+// Rest of record is skipped. Layout of record is same as in WRITEU.
+// Record that is not in direct access file is error.
+// If iostat is nil, then error of input is panic.
+//
+// Example:
+//
+//	READU(IOS, nil, &NSTEP, &TTIM, U)
+func READU(unit interface{}, iostat *int, a ...interface{}) {
+	DefaultUnits.READU(unit, iostat, a...)
+}
+
+// READU is function READU for table of units
+func (us *Units) READU(unit interface{}, iostat *int, a ...interface{}) {
+	us = us.get()
+	status(iostat, us.readu(unit, a))
+}
+
+func (us *Units) readu(unit interface{}, a []interface{}) error {
+	u, rec, err := us.lockedConnection(unit, "UNFORMATTED")
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	if u.action == "WRITE" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot read from file opened for WRITE")}
+	}
+	var record []byte
+	if rec != 0 {
+		record, err = u.readDirect(rec)()
+	} else {
+		record, err = unformattedRead(u.reader())
+	}
+	if err != nil {
+		return err
+	}
+	return decodeItems(record, a)
+}
+
+// unformattedRead return data of next record
+func unformattedRead(r io.Reader) ([]byte, error) {
+	marker := make([]byte, 4)
+	if _, err := io.ReadFull(r, marker); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = ioError{code: iostatCorrupt, err: fmt.Errorf(
+				"Unformatted file structure has been corrupted")}
+		}
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(marker)
+	record := make([]byte, int(size)+4)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, ioError{code: iostatCorrupt, err: fmt.Errorf(
+			"Unformatted file structure has been corrupted")}
+	}
+	if !bytes.Equal(record[size:], marker) {
+		return nil, ioError{code: iostatCorrupt, err: fmt.Errorf(
+			"Unformatted file structure has been corrupted: " +
+				"markers of record are not same")}
+	}
+	return record[:size], nil
+}
+
+// decodeItems store values of record in items
+func decodeItems(record []byte, a []interface{}) (err error) {
+	for i := range a {
+		elements(reflect.ValueOf(a[i]), func(v reflect.Value) {
+			if err != nil {
+				return
+			}
+			record, err = decode(record, v)
+		})
+		if err != nil {
+			return
+		}
+	}
+	return nil
+}
+
+// decode store value from begin of record in v and return rest of record
+func decode(record []byte, v reflect.Value) ([]byte, error) {
+	size := int(v.Type().Size())
+	if isCharacter(v) {
+		size = v.Len()
+	} else if v.Kind() == reflect.Int || v.Kind() == reflect.Bool {
+		size = 4
+	}
+	if len(record) < size {
+		return nil, ioError{code: iostatShortRecord, err: fmt.Errorf(
+			"I/O past end of record on unformatted file")}
+	}
+	if !v.CanSet() && !isCharacter(v) {
+		return nil, fmt.Errorf("Item of type %v is not settable", v.Type())
+	}
+	b := record[:size]
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range b {
+			v.Index(i).SetUint(uint64(b[i]))
+		}
+	case reflect.Bool:
+		v.SetBool(binary.LittleEndian.Uint32(b) != 0)
+	case reflect.Int, reflect.Int32:
+		v.SetInt(int64(int32(binary.LittleEndian.Uint32(b))))
+	case reflect.Int8:
+		v.SetInt(int64(int8(b[0])))
+	case reflect.Int16:
+		v.SetInt(int64(int16(binary.LittleEndian.Uint16(b))))
+	case reflect.Int64:
+		v.SetInt(int64(binary.LittleEndian.Uint64(b)))
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+	case reflect.Complex64:
+		re := math.Float32frombits(binary.LittleEndian.Uint32(b))
+		im := math.Float32frombits(binary.LittleEndian.Uint32(b[4:]))
+		v.SetComplex(complex(float64(re), float64(im)))
+	case reflect.Complex128:
+		re := math.Float64frombits(binary.LittleEndian.Uint64(b))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(b[8:]))
+		v.SetComplex(complex(re, im))
+	default:
+		return nil, fmt.Errorf("Type %v is not supported in unformatted input", v.Type())
+	}
+	return record[size:], nil
+}
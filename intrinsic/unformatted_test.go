@@ -0,0 +1,81 @@
+package intrinsic
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnformattedRecord(t *testing.T) {
+	record := unformattedRecord([]interface{}{
+		int(-2), []float32{1.5}, true, []byte("ab"), complex64(complex(1, 0)),
+	})
+	expect := []byte{
+		22, 0, 0, 0, // marker
+		0xfe, 0xff, 0xff, 0xff, // int
+		0, 0, 0xc0, 0x3f, // float32
+		1, 0, 0, 0, // logical
+		'a', 'b', // character
+		0, 0, 0x80, 0x3f, 0, 0, 0, 0, // complex
+		22, 0, 0, 0, // marker
+	}
+	if !bytes.Equal(record, expect) {
+		t.Errorf("Not valid record:\n%v\n%v", record, expect)
+	}
+}
+
+func TestUnformatted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "f4go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := []byte(filepath.Join(dir, "restart.bin"))
+
+	OPEN(10, nil, OpenSpec{FILE: file, FORM: []byte("UNFORMATTED")})
+	n, x, c := 3, 2.5, complex(1, -1)
+	a := [][]float64{{1, 2}, {3, 4}}
+	s := []byte("abc")
+	WRITEU(10, nil, &n, &x, a)
+	WRITEU(10, nil, &c, s, &n)
+	REWIND(10, nil)
+
+	n, x, c, s = 0, 0, 0, []byte("   ")
+	b := [][]float64{{0, 0}, {0, 0}}
+	READU(10, nil, &n, &x, b)
+	if n != 3 || x != 2.5 || b[1][0] != 3 || b[0][1] != 2 {
+		t.Errorf("Not valid first record: %v %v %v", n, x, b)
+	}
+	// rest of record is skipped
+	READU(10, nil, &c)
+	if c != complex(1, -1) {
+		t.Errorf("Not valid second record: %v", c)
+	}
+
+	var iostat int
+	READU(10, &iostat, &n)
+	if iostat != iostatEnd {
+		t.Errorf("Not valid status for end of file: %d", iostat)
+	}
+	REWIND(10, nil)
+	READU(10, &iostat, &n, &x, b, &n)
+	if iostat != iostatShortRecord {
+		t.Errorf("Not valid status for short record: %d", iostat)
+	}
+	READ(10, &iostat, nil, &n)
+	if iostat != iostatOptionConflict {
+		t.Errorf("Not valid status for formatted input: %d", iostat)
+	}
+	CLOSE(10, nil, nil)
+
+	// corrupted file
+	ioutil.WriteFile(string(file), []byte{8, 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0}, 0644)
+	OPEN(10, nil, OpenSpec{FILE: file, FORM: []byte("UNFORMATTED")})
+	READU(10, &iostat, &n)
+	if iostat != iostatCorrupt {
+		t.Errorf("Not valid status for corrupted file: %d", iostat)
+	}
+	CLOSE(10, nil, nil)
+}
@@ -0,0 +1,238 @@
+package intrinsic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Units is table of connections of unit numbers to files and streams.
+// Units 0, 5 and 6 are preconnected to standard error, input and
+// output. Units is safe for concurrent use, connection of unit is
+// found under lock of table and data are transferred under lock of
+// unit.
+//
+// Methods of nil table use DefaultUnits.
+//
+// Example:
+//
+//	us := intrinsic.NewUnits()
+//	out := us.Capture(6)
+//	us.WRITE(6, nil, nil, []byte("Hello"))
+//	fmt.Print(out.String()) // " Hello\n"
+type Units struct {
+	mu    sync.Mutex
+	table map[int]*unit
+}
+
+// DefaultUnits is table of units used by functions of package
+var DefaultUnits = NewUnits()
+
+// NewUnits return table of units with preconnected standard units
+func NewUnits() *Units {
+	return &Units{table: map[int]*unit{
+		0: preconnected(os.Stderr, "WRITE"),
+		5: preconnected(os.Stdin, "READ"),
+		6: preconnected(os.Stdout, "WRITE"),
+	}}
+}
+
+// get return table of units, nil table is DefaultUnits
+func (us *Units) get() *Units {
+	if us == nil {
+		return DefaultUnits
+	}
+	return us
+}
+
+// Register connect unit to reader for input and writer for output.
+// Reader or writer may be nil for unit only for output or input.
+// Connected unit is error.
+func (us *Units) Register(number int, r io.Reader, w io.Writer) error {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	if _, ok := us.table[number]; ok {
+		return fmt.Errorf("Unit %d is connected", number)
+	}
+	return us.register(number, r, w)
+}
+
+// Replace connect unit to reader for input and writer for output like
+// Register. Connected unit is closed before.
+func (us *Units) Replace(number int, r io.Reader, w io.Writer) error {
+	us = us.get()
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	if err := us.closeUnit(number, ""); err != nil {
+		return err
+	}
+	return us.register(number, r, w)
+}
+
+// Capture connect unit for output in buffer and return it.
+// Connected unit is closed before.
+func (us *Units) Capture(number int) *bytes.Buffer {
+	var buf bytes.Buffer
+	if err := us.Replace(number, nil, &buf); err != nil {
+		panic(err)
+	}
+	return &buf
+}
+
+func (us *Units) register(number int, r io.Reader, w io.Writer) error {
+	var action string
+	switch {
+	case r != nil && w != nil:
+		action = "READWRITE"
+	case r != nil:
+		action = "READ"
+	case w != nil:
+		action = "WRITE"
+	default:
+		return fmt.Errorf("Reader and writer of unit %d are nil", number)
+	}
+	u := &unit{
+		file:   streams{r: r, w: w},
+		access: "SEQUENTIAL",
+		form:   "FORMATTED",
+		action: action,
+		blank:  'N',
+		pad:    true,
+	}
+	us.table[number] = u
+	return nil
+}
+
+type unitsKey struct{}
+
+// WithUnits return copy of context with table of units.
+// Table of units in context is used for reentrant code, when each
+// call has own units.
+func WithUnits(ctx context.Context, us *Units) context.Context {
+	return context.WithValue(ctx, unitsKey{}, us)
+}
+
+// UnitsFrom return table of units from context or DefaultUnits
+func UnitsFrom(ctx context.Context) *Units {
+	if us, ok := ctx.Value(unitsKey{}).(*Units); ok && us != nil {
+		return us
+	}
+	return DefaultUnits
+}
+
+// stream is file or stream of connection
+type stream interface {
+	io.Reader
+	io.Writer
+}
+
+// streams is stream of reader and writer
+type streams struct {
+	r io.Reader
+	w io.Writer
+}
+
+func (s streams) Read(b []byte) (int, error) {
+	if s.r == nil {
+		return 0, fmt.Errorf("Cannot read from unit for output")
+	}
+	return s.r.Read(b)
+}
+
+func (s streams) Write(b []byte) (int, error) {
+	if s.w == nil {
+		return 0, fmt.Errorf("Cannot write to unit for input")
+	}
+	return s.w.Write(b)
+}
+
+// Operations of file, which are not supported by all streams
+
+func (u *unit) notSupported(operation string) error {
+	return fmt.Errorf("Operation %s is not supported by unit %q", operation, u.name)
+}
+
+// reader return buffered reader of sequential input
+func (u *unit) reader() *bufio.Reader {
+	if u.r == nil {
+		u.r = bufio.NewReader(u.file)
+	}
+	return u.r
+}
+
+// write write data in file. Position of file is position of unit, so
+// data buffered for reading are discarded before.
+func (u *unit) write(b []byte) error {
+	if u.r != nil && u.r.Buffered() > 0 {
+		if _, ok := u.seeker(); ok {
+			if _, err := u.seek(0, io.SeekCurrent); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := u.file.Write(b)
+	return err
+}
+
+// seeker return seeker of file
+func (u *unit) seeker() (io.Seeker, bool) {
+	if s, ok := u.file.(io.Seeker); ok {
+		return s, true
+	}
+	if s, ok := u.file.(streams); ok && s.w == nil {
+		// reader for input only
+		if r, ok := s.r.(io.Seeker); ok {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// seek set position of unit. Position of unit is position of file
+// without data buffered for reading, buffer is discarded.
+func (u *unit) seek(offset int64, whence int) (int64, error) {
+	s, ok := u.seeker()
+	if !ok {
+		return 0, u.notSupported("seek")
+	}
+	if u.r != nil {
+		if whence == io.SeekCurrent {
+			offset -= int64(u.r.Buffered())
+		}
+		u.r.Reset(u.file)
+	}
+	return s.Seek(offset, whence)
+}
+
+func (u *unit) readAt(b []byte, offset int64) (int, error) {
+	if r, ok := u.file.(io.ReaderAt); ok {
+		return r.ReadAt(b, offset)
+	}
+	return 0, u.notSupported("read at position")
+}
+
+func (u *unit) writeAt(b []byte, offset int64) (int, error) {
+	if w, ok := u.file.(io.WriterAt); ok {
+		return w.WriteAt(b, offset)
+	}
+	return 0, u.notSupported("write at position")
+}
+
+func (u *unit) stat() (os.FileInfo, error) {
+	if f, ok := u.file.(interface{ Stat() (os.FileInfo, error) }); ok {
+		return f.Stat()
+	}
+	return nil, u.notSupported("stat")
+}
+
+func (u *unit) truncate(size int64) error {
+	if f, ok := u.file.(interface{ Truncate(int64) error }); ok {
+		return f.Truncate(size)
+	}
+	return u.notSupported("truncate")
+}
@@ -0,0 +1,101 @@
+package intrinsic
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUnits(t *testing.T) {
+	us := NewUnits()
+	out := us.Capture(6)
+	if err := us.Register(5, strings.NewReader("42 1.5\n7\n"), nil); err == nil {
+		t.Errorf("Unit 5 is preconnected")
+	}
+	if err := us.Replace(5, strings.NewReader("42 1.5\n7\n"), nil); err != nil {
+		t.Fatal(err)
+	}
+	var i int
+	var x float64
+	us.READ(5, nil, nil, &i, &x)
+	us.WRITE(6, nil, []byte("(I3,F5.1)"), i, x)
+	us.REWIND(5, nil)
+	us.READ(5, nil, []byte("(I2)"), &i)
+	us.WRITE(6, nil, nil, i)
+	if s := out.String(); s != " 42  1.5\n          42\n" {
+		t.Errorf("Not valid output: %q", s)
+	}
+
+	var iostat int
+	us.READ(6, &iostat, nil, &i)
+	if iostat != iostatOptionConflict {
+		t.Errorf("Not valid status for input from output unit: %d", iostat)
+	}
+	us.CLOSE(5, nil, nil)
+	if err := us.Register(10, nil, nil); err == nil {
+		t.Errorf("Register without reader and writer")
+	}
+
+	// nil table and context
+	if UnitsFrom(context.Background()) != DefaultUnits || (*Units)(nil).get() != DefaultUnits {
+		t.Errorf("Not default table")
+	}
+	if UnitsFrom(WithUnits(context.Background(), us)) != us {
+		t.Errorf("Not table of context")
+	}
+}
+
+func TestUnitsConcurrent(t *testing.T) {
+	us := NewUnits()
+	out := us.Capture(6)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				us.WRITE(6, nil, []byte("(I3,A)"), i, []byte("abc"))
+			}
+		}(i)
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 1000 {
+		t.Fatalf("Not valid amount of records: %d", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) != 6 || !strings.HasSuffix(line, "abc") {
+			t.Errorf("Not valid record: %q", line)
+		}
+	}
+}
+
+func TestUnitsPipe(t *testing.T) {
+	// input of unit is blocked until output in other unit
+	us := NewUnits()
+	r, w := io.Pipe()
+	if err := us.Register(10, r, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := us.Register(11, nil, w); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan int)
+	go func() {
+		var i int
+		us.READ(10, nil, nil, &i)
+		done <- i
+	}()
+	us.WRITE(11, nil, []byte("(I3)"), 42)
+	select {
+	case i := <-done:
+		if i != 42 {
+			t.Errorf("Not valid input from pipe: %d", i)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Input from pipe is blocked")
+	}
+}
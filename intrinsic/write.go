@@ -3,78 +3,75 @@ package intrinsic
 import (
 	"bytes"
 	"fmt"
-	"os"
-	"reflect"
 )
 
-var units map[int]*os.File
-
-func init() {
-	units = map[int]*os.File{}
-	units[6] = os.Stdout
+// WRITE is output of items in unit by format.
+// Format nil is list-directed output. Unit is number of unit, Record
+// of direct access file or CHARACTER variable of internal file.
+// If iostat is nil, then error of output is panic.
+//
+// Example:
+//
+//	WRITE(6, nil, []byte("(' iterator = ', I2)"), I)
+//	WRITE(Record{Unit: 10, REC: 2}, &iostat, []byte("(3F10.2)"), X)
+//	WRITE((*STR), nil, []byte("(I5)"), N)
+func WRITE(unit interface{}, iostat *int, format []byte, a ...interface{}) {
+	DefaultUnits.WRITE(unit, iostat, format, a...)
 }
 
-func REWIND(unit int) {
-	units[unit].Seek(0, 0)
+// WRITE is function WRITE for table of units
+func (us *Units) WRITE(unit interface{}, iostat *int, format []byte, a ...interface{}) {
+	if records, ok := internal(unit); ok {
+		status(iostat, writeInternal(records, format, a))
+		return
+	}
+	us = us.get()
+	status(iostat, us.write(unit, format, a))
 }
 
-func WRITE(unit int, format []byte, a ...interface{}) {
-again:
-	for i := 1; i < len(a); i++ {
-		b1, ok1 := a[i].([]byte)
-		for j := i + 1; j < len(a); j++ {
-			b2, ok2 := a[j].([]byte)
-			if ok1 && ok2 {
-				b1 = append(b1, b2...)
-				a = append(a[:i], append([]interface{}{b1}, a[i+1:]...)...)
-				goto again
-			}
-		}
+func (us *Units) write(unit interface{}, format []byte, a []interface{}) error {
+	u, rec, err := us.lockedConnection(unit, "FORMATTED")
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	if err := u.writable(); err != nil {
+		return err
 	}
 
-	for i := range a {
-		if str, ok := a[i].([]byte); ok {
-			a[i] = string(str)
+	var records [][]byte
+	advance := true
+	switch {
+	case format != nil:
+		records, advance, err = formatWrite(format, a)
+		if err != nil {
+			return err
 		}
+	case rec != 0:
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"List-directed output is not allowed for direct access")}
+	default:
+		records = [][]byte{listWrite(a, u.delim)}
 	}
-
-	fmt.Fprintf(units[unit], string(format), a...)
-}
-
-func OPEN(unit int, file []byte) {
-	f, err := os.Open(string(file))
-	if err != nil {
-		panic(err)
+	if rec != 0 {
+		return u.writeDirect(rec, records, ' ')
 	}
-	units[unit] = f
-}
-
-func CLOSE(unit int) {
-	delete(units, unit)
-}
-
-func READ(unit *int, format []byte, a ...interface{}) {
-
-	format = bytes.TrimSpace(bytes.ToLower(format))
-
-	// Change from %15.4f to %15f
-	for i := 1; i < 20; i++ {
-		for j := 1; j <= i; j++ {
-			format = bytes.Replace(format,
-				[]byte(fmt.Sprintf("%c%d.%df", '%', i, j)),
-				[]byte(fmt.Sprintf("%cf", '%')),
-				-1)
+	var buf bytes.Buffer
+	for i := range records {
+		buf.Write(records[i])
+		if i < len(records)-1 || advance {
+			buf.WriteByte('\n')
 		}
 	}
+	err = u.write(buf.Bytes())
+	return err
+}
 
-	ft := string(format)
-	_, err := fmt.Fscanf(units[*unit], ft, a...)
-	if err != nil {
-		var types string
-		for i := range a {
-			types += fmt.Sprintf("|%s|", reflect.TypeOf(a[i]))
-		}
-		panic(fmt.Errorf("READ error for format `%s` : %v\nValues = %v",
-			ft, err, types))
+// writable return error, if unit is opened only for input
+func (u *unit) writable() error {
+	if u.action == "READ" {
+		return ioError{code: iostatOptionConflict, err: fmt.Errorf(
+			"Cannot write to file opened for READ")}
 	}
+	return nil
 }
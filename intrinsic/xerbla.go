@@ -0,0 +1,29 @@
+package intrinsic
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// XerblaError is error of routine with illegal value of argument.
+type XerblaError struct {
+	// Routine is name of routine
+	Routine string
+	// Info is position of argument with illegal value
+	Info int
+}
+
+func (e XerblaError) Error() string {
+	return fmt.Sprintf("** On entry to %s parameter number %2d had an illegal value",
+		e.Routine, e.Info)
+}
+
+// XERBLA is error handler for LAPACK routines.
+// Fortran routine prints message and stops the program,
+// but that implementation returns error.
+func XERBLA(srname []byte, info int) error {
+	return XerblaError{
+		Routine: string(bytes.TrimRight(srname, " ")),
+		Info:    info,
+	}
+}
@@ -4,12 +4,12 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	goast "go/ast"
 	"go/format"
 	"go/token"
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"regexp"
 	"runtime"
 	"runtime/debug"
 	"strings"
@@ -20,6 +20,9 @@ import (
 var (
 	packageFlag  *string
 	simplifyFlag *bool
+	errorFlag    *bool
+	wrapperFlag  *bool
+	stateFlag    *bool
 	parallelFlag *int
 	verboseFlag  *int
 )
@@ -35,7 +38,13 @@ func main() {
 	packageFlag = flag.String("p",
 		"main", "set the name of the generated package")
 	simplifyFlag = flag.Bool("s",
-		false, "simplify from (*I) to I of Golang file. NOT guaranteed to work correctly")
+		false, "pass arguments of functions by value, if they are not changed")
+	errorFlag = flag.Bool("e",
+		false, "return error from subroutines with XERBLA instead of STOP")
+	wrapperFlag = flag.Bool("w",
+		false, "add wrappers of functions with inputs by value and outputs as results")
+	stateFlag = flag.Bool("r",
+		false, "generate reentrant code with global state in struct State")
 	parallelFlag = flag.Int("m",
 		1, "enable parallelism in file processing. Default is only one core")
 	verboseFlag = flag.Int("v",
@@ -63,7 +72,9 @@ func run() {
 		packageFlag = &s
 	}
 	var es []errorRow
-	if *parallelFlag > 1 {
+	if *simplifyFlag || *errorFlag || *wrapperFlag || *stateFlag {
+		es = parsePackage(flag.Args(), *packageFlag)
+	} else if *parallelFlag > 1 {
 		es = parseParallel(flag.Args(), *packageFlag)
 	} else {
 		for _, s := range flag.Args() {
@@ -89,6 +100,18 @@ func (err errorRow) Error() string {
 
 // parsing to Go code
 func parse(filename, packageName, goFilename string) (errR []errorRow) {
+	ast, errR := translate(filename, packageName)
+	if ast == nil {
+		return
+	}
+
+	postProcess([]*goast.File{ast})
+
+	return append(errR, write(ast, filename, goFilename)...)
+}
+
+// translate fortran source to go/ast
+func translate(filename, packageName string) (_ *goast.File, errR []errorRow) {
 	defer func() {
 		err := recover()
 		if err != nil {
@@ -104,7 +127,7 @@ func parse(filename, packageName, goFilename string) (errR []errorRow) {
 	// read fortran source
 	dat, err := ioutil.ReadFile(filename)
 	if err != nil {
-		return []errorRow{
+		return nil, []errorRow{
 			{
 				err:      fmt.Errorf("Cannot fortran source: %v", err),
 				filename: filename,
@@ -126,10 +149,14 @@ func parse(filename, packageName, goFilename string) (errR []errorRow) {
 			})
 		}
 	}
+	return &ast, errR
+}
 
+// write go/ast to Go source file
+func write(ast *goast.File, filename, goFilename string) (errR []errorRow) {
 	// convert ast tree to string
 	var buf bytes.Buffer
-	if err = format.Node(&buf, token.NewFileSet(), &ast); err != nil {
+	if err := format.Node(&buf, token.NewFileSet(), ast); err != nil {
 		return []errorRow{{err: fmt.Errorf("Error go/format : %v", err), filename: filename}}
 	}
 
@@ -143,7 +170,7 @@ func parse(filename, packageName, goFilename string) (errR []errorRow) {
 	}
 
 	// save go source
-	if err = ioutil.WriteFile(goFilename, buf.Bytes(), 0644); err != nil {
+	if err := ioutil.WriteFile(goFilename, buf.Bytes(), 0644); err != nil {
 		return []errorRow{{err: fmt.Errorf("Cannot write Go source: %v", err), filename: filename}}
 	}
 
@@ -153,13 +180,30 @@ func parse(filename, packageName, goFilename string) (errR []errorRow) {
 	// goimports
 	_, _ = exec.Command("goimport", "-w", goFilename).CombinedOutput()
 
-	if simplifyFlag == nil {
-		simplifyFlag = new(bool)
-	}
-	if *simplifyFlag {
-		simplify(goFilename)
+	return
+}
+
+// parsePackage parse all files together, because post-processing
+// changes signatures of functions in all files
+func parsePackage(filenames []string, packageName string) (ess []errorRow) {
+	asts := make([]*goast.File, 0, len(filenames))
+	names := make([]string, 0, len(filenames))
+	for _, f := range filenames {
+		fortran.Logf("parsing file %s\n", f)
+		ast, es := translate(f, packageName)
+		ess = append(ess, es...)
+		if ast == nil {
+			continue
+		}
+		asts = append(asts, ast)
+		names = append(names, f)
 	}
 
+	postProcess(asts)
+
+	for i := range asts {
+		ess = append(ess, write(asts[i], names[i], "")...)
+	}
 	return
 }
 
@@ -188,46 +232,18 @@ func parseParallel(filenames []string, packageName string) (ess []errorRow) {
 	return
 }
 
-func simplify(filename string) {
-	// read file
-	content, err := ioutil.ReadFile(filename)
-	if err != nil {
-		panic(err)
-	}
-
-	{
-		// from : (*(L)) -> L
-		re := regexp.MustCompile(`\(\*\((?P<name>[[:word:]]*)\)\)`)
-		content = re.ReplaceAll(content, []byte("$1"))
+// postProcess run changes of go/ast for all files of package
+func postProcess(asts []*goast.File) {
+	if errorFlag != nil && *errorFlag {
+		fortran.ErrorReturns(asts)
 	}
-	{
-		// from : (*K)   -> K
-		re := regexp.MustCompile(`\(\*(?P<name>[[:word:]]*)\)`)
-		content = re.ReplaceAll(content, []byte("$1"))
+	if simplifyFlag != nil && *simplifyFlag {
+		fortran.Simplify(asts)
 	}
-	{
-		// from: A := new(int) -> var A int
-		re := regexp.MustCompile(`(?P<name>[[:word:]]*) := new\((?P<typ>[[:word:]]*)\)`)
-		content = re.ReplaceAll(content, []byte("var $1 $2"))
+	if wrapperFlag != nil && *wrapperFlag {
+		fortran.Wrappers(asts)
 	}
-	{
-		// from: (123) -> 123
-		re := regexp.MustCompile(`\((?P<name>[[:digit:]]*)\)`)
-		content = re.ReplaceAll(content, []byte("$1"))
-	}
-	{
-		// *int
-		content = bytes.ReplaceAll(content, []byte("*int"), []byte("int"))
-		content = bytes.ReplaceAll(content, []byte("*float"), []byte("float"))
-	}
-	{
-		// *[]int
-		content = bytes.ReplaceAll(content, []byte("*[]"), []byte("[]"))
-	}
-
-	// write file
-	err = ioutil.WriteFile(filename, content, 0644)
-	if err != nil {
-		panic(err)
+	if stateFlag != nil && *stateFlag {
+		fortran.Reentrant(asts)
 	}
 }
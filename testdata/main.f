@@ -135,6 +135,9 @@ C           call testName("test_dimension")
         
 C           call testName("test_call_left_part")
             call test_call_left_part()
+
+C           call testName("test_function_value")
+            call test_function_value()
         
 C           call testName("test_write_loop")
 C           call test_write_loop()
@@ -259,27 +262,27 @@ C                WRITE(*,*) "ELSEIF is Ok"
                 IF (.NOT.l) THEN ! TEST COMMENT
                 ELSE 
                     IF (J.GE.0) THEN 
-                        CALL F4GOTESTOK ! WRITE(*,*) "Ok"
+                        WRITE(*,*) "Ok"
                     END IF
                 END IF
   100       CONTINUE
 
             DO 150 J = 1,2
                     TT = I + 150
-                    CALL F4GOTESTOK ! WRITE(*,'(I5)') TT
+                    WRITE(*,'(I5)') TT
                     TT = J + 150
-                    CALL F4GOTESTOK ! WRITE(*,'(I5)') TT
+                    WRITE(*,'(I5)') TT
                 DO 130 I = 1,2
-                    CALL F4GOTESTOK ! WRITE(*,'(I2)') I
-                    CALL F4GOTESTOK ! WRITE(*,'(I2)') J
+                    WRITE(*,'(I2)') I
+                    WRITE(*,'(I2)') J
                     IF (I .EQ. 1) GOTO 130
-                    CALL F4GOTESTOK ! WRITE(*,'(I2)') I
-                    CALL F4GOTESTOK ! WRITE(*,'(I2)') J
+                    WRITE(*,'(I2)') I
+                    WRITE(*,'(I2)') J
   130 CONTINUE
                     TT = I + 130
-                    CALL F4GOTESTOK ! WRITE(*,'(I5)') TT
+                    WRITE(*,'(I5)') TT
                     TT = J + 130
-                    CALL F4GOTESTOK ! WRITE(*,'(I5)') TT
+                    WRITE(*,'(I5)') TT
   150 CONTINUE
 
             CALL ZD()
@@ -291,7 +294,7 @@ C                WRITE(*,*) "ELSEIF is Ok"
 			INTEGER IFORM
             DO 100 IFORM = 1, 2
       			IF ( IFORM .NE. 0 ) THEN
-                    CALL F4GOTESTOK ! WRITE(*,'(I1)')IFORM
+                    WRITE(*,'(I1)')IFORM
                 END IF
   100       CONTINUE
         RETURN
@@ -326,17 +329,17 @@ C -----------------------------------------------------
   130           END DO
   140       END DO
             Do IR = 1,10,3
-                CALL F4GOTESTOK ! write (*,FMT=142) IR
+                write (*,FMT=142) IR
             end do
             Do IR = 1,3
-                CALL F4GOTESTOK ! write (*,FMT=146) IR
+                write (*,FMT=146) IR
             end do
             DO 143 IR = 1,3
-                CALL F4GOTESTOK ! write (*,FMT=147) IR
+                write (*,FMT=147) IR
   143 Continue
   144 Continue
             Do IR = 1,3
-                CALL F4GOTESTOK ! write (*,FMT=148) IR
+                write (*,FMT=148) IR
             end do
 
             if (ab_min(3,14) .EQ. 14) THEN
@@ -359,16 +362,16 @@ C           call funcwrt(2,a(1,1))
             call NOPAREN
 
             Do IR = 1,ab_min(ab_min(3,13),1000)
-                CALL F4GOTESTOK ! write (*,FMT=149) IR
+                write (*,FMT=149) IR
             enddo
             DO 145, HR = 1,2
                 DO 145 JR = 1,2
-                    CALL F4GOTESTOK ! write(*,FMT=150) HR, JR
+                    write(*,FMT=150) HR, JR
   145 Continue
-            CALL F4GOTESTOK ! write (*,fmt=151)iterator
+            write (*,fmt=151)iterator
             iterator = iterator + 1
             IF ( iterator .LE. 3) THEN
-                CALL F4GOTESTOK ! write(*,*) "iterator is less or equal 3"
+                write(*,*) "iterator is less or equal 3"
                 GO TO 144
             END IF
             call test_do2()
@@ -385,14 +388,14 @@ C           call funcwrt(2,a(1,1))
          SUBROUTINE funcwrt(LEN, a)
              INTEGER LEN
              INTEGER a(LEN)
-             CALL F4GOTESTOK ! WRITE(*,*) "start of funcwrt"
+             WRITE(*,*) "start of funcwrt"
              CALL F4GOTESTOK ! WRITE(*,'(I2)') a(1)
              CALL F4GOTESTOK ! WRITE(*,'(I2)') a(2)
-             CALL F4GOTESTOK ! WRITE(*,*) "end   of funcwrt"
+             WRITE(*,*) "end   of funcwrt"
          END SUBROUTINE
 
          SUBROUTINE NOPAREN
-             CALL F4GOTESTOK ! WRITE(*,*) "NOPAREN"
+             WRITE(*,*) "NOPAREN"
          END SUBROUTINE
 
          integer function funarr(a)
@@ -409,10 +412,10 @@ C           call funcwrt(2,a(1,1))
             integer a
             funcell = a + 1
             a = 9
-            CALL F4GOTESTOK ! WRITE(*,*)      "funcell"
+            WRITE(*,*)      "funcell"
             CALL F4GOTESTOK ! WRITE(*,'(I2)') a
             CALL F4GOTESTOK ! WRITE(*,'(I2)') funcell
-            CALL F4GOTESTOK ! WRITE(*,*)      "end of funcell"
+            WRITE(*,*)      "end of funcell"
             return
          end function
 
@@ -801,21 +804,21 @@ C -----------------------------------------------------
             I4 = 12
             I8 = 12
             if ( 45.0 .LE. R1 .AND. R1 .LE. 45.2) THEN
-                CALL F4GOTESTOK ! write(*,*)'R1 ... ok'
+                write(*,*)'R1 ... ok'
             end if
             if ( 45.0 .LE. R4 .AND. R4 .LE. 45.2) THEN
-                CALL F4GOTESTOK ! write(*,*)'R4 ... ok'
+                write(*,*)'R4 ... ok'
             end if
             if ( 45.0 .LE. R8 .AND. R8 .LE. 45.2) THEN
-                CALL F4GOTESTOK ! write(*,*)'R8 ... ok'
+                write(*,*)'R8 ... ok'
             end if
             if ( 45.0 .LE. DP .AND. DP .LE. 45.2) THEN
-                CALL F4GOTESTOK ! write(*,*)'DP ... ok'
+                write(*,*)'DP ... ok'
             end if
-            if ( I1 .Eq. 12) CALL F4GOTESTOK ! write(*,*)'I1 ... ok'
-            if ( I2 .Eq. 12) CALL F4GOTESTOK ! write(*,*)'I2 ... ok'
-            if ( I4 .Eq. 12) CALL F4GOTESTOK ! write(*,*)'I4 ... ok'
-            if ( I8 .Eq. 12) CALL F4GOTESTOK ! write(*,*)'I8 ... ok'
+            if ( I1 .Eq. 12) write(*,*)'I1 ... ok'
+            if ( I2 .Eq. 12) write(*,*)'I2 ... ok'
+            if ( I4 .Eq. 12) write(*,*)'I4 ... ok'
+            if ( I8 .Eq. 12) write(*,*)'I8 ... ok'
             return
         end subroutine
 
@@ -877,12 +880,12 @@ C -----------------------------------------------------
             c2 = (3.23,-5.666)
             c1 = c1 + c2
             CALL F4GOTESTOK ! write(*,fmt = 500) real(ONE), aimag(ONE)
-            CALL F4GOTESTOK ! WRITE(*,*)'CONJG'
+            WRITE(*,*)'CONJG'
             CALL F4GOTESTOK ! write(*,fmt = 500) real(c1),  aimag(c1)
             c1 = conjg(c1)
             CALL F4GOTESTOK ! write(*,fmt = 500) real(c1),  aimag(c1)
             zc = (-2.333,5.666)
-            CALL F4GOTESTOK ! WRITE(*,*)'DCONJG'
+            WRITE(*,*)'DCONJG'
             CALL F4GOTESTOK ! write(*,fmt = 500) real(zc),  aimag(zc)
             zc = dconjg(zc)
             CALL F4GOTESTOK ! write(*,fmt = 500) real(zc),  aimag(zc)
@@ -897,7 +900,7 @@ C -----------------------------------------------------
             CALL F4GOTESTOK ! write(*,fmt = 500) real(db1), aimag(db1)
             CALL F4GOTESTOK ! write(*,fmt = 500) real(db2), aimag(db2)
 
-            CALL F4GOTESTOK ! write(*,*) "==== REAL * COMPLEX ===="
+            write(*,*) "==== REAL * COMPLEX ===="
             CR = (0.12,0.34)
             R = 12.34
             CR = R * CR
@@ -1335,6 +1338,32 @@ C           P=SQRT(C)
 C           CALL F4GOTESTOK ! WRITE(*,'(F8.2)') P
         END 
 
+C -----------------------------------------------------
+
+        SUBROUTINE test_function_value
+            INTEGER I, S, ARR(3), AB_MIN
+            EXTERNAL AB_MIN
+            ARR(1) = 10
+            ARR(2) = 20
+            ARR(3) = 30
+            ! function in index of array
+            IF (ARR(AB_MIN(2,3)) .NE. 20) CALL F4GOTESTFAIL
+            ! function as argument of function
+            IF (AB_MIN(AB_MIN(5,4),3) .NE. 3) CALL F4GOTESTFAIL
+            IF (AB_MIN(3,AB_MIN(5,4)) .NE. 3) CALL F4GOTESTFAIL
+            ! functions in binary expression
+            IF (AB_MIN(1,2) + AB_MIN(3,4) .NE. 4) CALL F4GOTESTFAIL
+            I = 2 * AB_MIN(7,8) - AB_MIN(9,10)
+            IF (I .NE. 5) CALL F4GOTESTFAIL
+            ! function in bounds of loop
+            S = 0
+            DO I = AB_MIN(1,2), AB_MIN(3,5)
+                S = S + I
+            END DO
+            IF (S .NE. 6) CALL F4GOTESTFAIL
+            CALL F4GOTESTOK
+        END
+
 C -----------------------------------------------------
 
 C       SUBROUTINE test_write_loop